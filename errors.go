@@ -35,10 +35,68 @@ const (
 
 // =============== Tree BST errors ===============
 const (
-	ErrNilBstNode sErr = "tree BST: node is nil"
+	ErrNilBstNode        sErr = "tree BST: node is nil"
+	ErrBstLengthMismatch sErr = "tree BST: values and data lengths mismatch"
+	ErrBstNotSorted      sErr = "tree BST: values are not strictly ascending"
+	ErrBstJoinOverlap    sErr = "tree BST: joined trees value ranges overlap"
+)
+
+// =============== Tree B-tree errors ===============
+const (
+	ErrBTreeInvalidDegree sErr = "tree B-tree: degree must be above 1"
+)
+
+// =============== Tree interval errors ===============
+const (
+	ErrIntervalInvalidBounds sErr = "tree interval: low bound is above high bound"
+)
+
+// =============== Tree segment errors ===============
+func ErrSegmentOutOfRange(l, r, n int) sErr {
+	return newSErr("tree segment: range [%d, %d) out of length %d", l, r, n)
+}
+
+// =============== Tree treap errors ===============
+func ErrTreapOutOfRange(i, n int) sErr {
+	return newSErr("tree treap: index out of range %d with length %d", i, n)
+}
+
+func ErrTreapInvalidRange(l, r, n int) sErr {
+	return newSErr("tree treap: invalid range [%d, %d) of length %d", l, r, n)
+}
+
+// =============== Tree fenwick errors ===============
+func ErrFenwickOutOfRange(i, n int) sErr {
+	return newSErr("tree fenwick: index %d out of length %d", i, n)
+}
+
+func ErrFenwickInvalidSize(n int) sErr {
+	return newSErr("tree fenwick: length %d must not be negative", n)
+}
+
+func ErrFenwickInvalidRange(l, r, n int) sErr {
+	return newSErr("tree fenwick: invalid range [%d, %d) of length %d", l, r, n)
+}
+
+// =============== Tree k-d errors ===============
+const (
+	ErrKDLengthMismatch sErr = "tree k-d: points and data lengths mismatch"
+)
+
+func ErrKDDimMismatch(dim, got int) sErr {
+	return newSErr("tree k-d: point dimension %d mismatch with tree dimension %d", got, dim)
+}
+
+// =============== Tree spatial errors ===============
+const (
+	ErrSpatialInvalidBounds sErr = "tree spatial: bounds sizes must be above zero"
 )
 
 // =============== Matrix errors ===============
+const (
+	ErrMatSingular sErr = "2d matrix: matrix is singular"
+)
+
 func ErrMatUnequalShapes(rank int) sErr {
 	return newSErr("%dd matrix: not equal matrix shapes", rank)
 }
@@ -54,3 +112,31 @@ func ErrMatNegativeCoords(rank int) sErr {
 func ErrMatOutCoords(rank int) sErr {
 	return newSErr("%dd matrix: coords out of matrix range", rank)
 }
+
+func ErrMatMulShapes(a, b []int) sErr {
+	return newSErr("2d matrix: product shapes %v and %v mismatch", a, b)
+}
+
+func ErrMatMulRank(rank int) sErr {
+	return newSErr("%dd matrix: product is defined only for 2d matrices", rank)
+}
+
+func ErrMatVecLength(rank, width, got int) sErr {
+	return newSErr("%dd matrix: vector length %d mismatch with width %d", rank, got, width)
+}
+
+func ErrMatReshapeSize(rank, size int, shape []int) sErr {
+	return newSErr("%dd matrix: can not reshape %d elements to shape %v", rank, size, shape)
+}
+
+func ErrMatNotContiguous(rank int) sErr {
+	return newSErr("%dd matrix: view is not contiguous, materialize it first", rank)
+}
+
+func ErrMatInvalidAxes(rank int, axes []int) sErr {
+	return newSErr("%dd matrix: axes %v are not a permutation", rank, axes)
+}
+
+func ErrMatNotSquare(shape []int) sErr {
+	return newSErr("%dd matrix: shape %v is not a square 2d matrix", len(shape), shape)
+}
@@ -0,0 +1,216 @@
+package somedata
+
+import (
+	"cmp"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Handle - reference to heap element, stays valid until element is removed
+type Handle[T any] struct {
+	value T
+	index int // position in heap, -1 when removed
+}
+
+// Value - element value
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// Alive - element is still stored in heap
+func (h *Handle[T]) Alive() bool {
+	return h != nil && h.index >= 0
+}
+
+/*
+heap - binary heap ordered by comparator.
+Root is the element with the least value by cmp, so min-heap is built
+with cmp.Compare and max-heap with reversed comparison. Push, Pop, Update
+and Remove are O(log n), Peek is O(1).
+*/
+type heap[T any] struct {
+	items []*Handle[T]
+	cmp   func(a, b T) int
+}
+
+// NewMinHeap - creates heap popping the least values first
+func NewMinHeap[T constraints.Ordered]() *heap[T] {
+	return NewHeapFunc(cmp.Compare[T])
+}
+
+// NewMaxHeap - creates heap popping the greatest values first
+func NewMaxHeap[T constraints.Ordered]() *heap[T] {
+	return NewHeapFunc(func(a, b T) int { return cmp.Compare(b, a) })
+}
+
+// NewHeapFunc - creates heap popping the least values by cmp first,
+// cmp has the same contract as cmp.Compare
+func NewHeapFunc[T any](cmp func(a, b T) int) *heap[T] {
+	return &heap[T]{cmp: cmp}
+}
+
+// Len - elements count
+func (h *heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Heapify - replaces heap content by values in O(n),
+// returns handles in the same order as values
+func (h *heap[T]) Heapify(values []T) []*Handle[T] {
+	for _, it := range h.items {
+		it.index = -1
+	}
+
+	h.items = make([]*Handle[T], len(values))
+	for i, v := range values {
+		h.items[i] = &Handle[T]{value: v, index: i}
+	}
+
+	handles := make([]*Handle[T], len(values))
+	copy(handles, h.items)
+
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return handles
+}
+
+// Push - adds value
+func (h *heap[T]) Push(value T) *Handle[T] {
+	it := &Handle[T]{value: value, index: len(h.items)}
+	h.items = append(h.items, it)
+	h.up(it.index)
+	return it
+}
+
+// Peek - root value without removing
+func (h *heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0].value, true
+}
+
+// Pop - removes and returns root value
+func (h *heap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// Update - replaces value of element and restores heap order
+func (h *heap[T]) Update(it *Handle[T], value T) bool {
+	if !h.owns(it) {
+		return false
+	}
+	it.value = value
+	h.fix(it.index)
+	return true
+}
+
+// Fix - restores heap order after value behind handle was changed in place
+func (h *heap[T]) Fix(it *Handle[T]) bool {
+	if !h.owns(it) {
+		return false
+	}
+	h.fix(it.index)
+	return true
+}
+
+// Remove - removes element by handle
+func (h *heap[T]) Remove(it *Handle[T]) (T, bool) {
+	if !h.owns(it) {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(it.index), true
+}
+
+// Drain - iterator popping elements in priority order,
+// elements left after early break stay in heap
+func (h *heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(h.items) != 0 {
+			if !yield(h.removeAt(0)) {
+				return
+			}
+		}
+	}
+}
+
+func (h *heap[T]) owns(it *Handle[T]) bool {
+	return it.Alive() && it.index < len(h.items) && h.items[it.index] == it
+}
+
+func (h *heap[T]) removeAt(i int) T {
+	last := len(h.items) - 1
+	it := h.items[i]
+
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last {
+		h.fix(i)
+	}
+
+	it.index = -1
+	return it.value
+}
+
+func (h *heap[T]) less(i, j int) bool {
+	return h.cmp(h.items[i].value, h.items[j].value) < 0
+}
+
+func (h *heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+func (h *heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(i, parent) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down - sifts element down, returns true if it was moved
+func (h *heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+
+	for {
+		low := 2*i + 1
+		if low >= n {
+			break
+		}
+
+		least := low
+		if hight := low + 1; hight < n && h.less(hight, low) {
+			least = hight
+		}
+		if !h.less(least, i) {
+			break
+		}
+
+		h.swap(i, least)
+		i = least
+	}
+	return i > start
+}
@@ -0,0 +1,118 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	somedata "github.com/eterline/somedata/heap"
+)
+
+func TestHeap_MinMax(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2, 7}
+
+	min := somedata.NewMinHeap[int]()
+	max := somedata.NewMaxHeap[int]()
+	for _, v := range values {
+		min.Push(v)
+		max.Push(v)
+	}
+
+	if v, ok := min.Peek(); !ok || v != 1 {
+		t.Fatalf("min Peek: expected 1, got %d", v)
+	}
+
+	got := slices.Collect(min.Drain())
+	if !slices.Equal(got, []int{1, 2, 3, 5, 7, 8, 9}) || min.Len() != 0 {
+		t.Fatalf("min Drain: got %v", got)
+	}
+
+	got = slices.Collect(max.Drain())
+	if !slices.Equal(got, []int{9, 8, 7, 5, 3, 2, 1}) {
+		t.Fatalf("max Drain: got %v", got)
+	}
+
+	if _, ok := min.Pop(); ok {
+		t.Fatalf("Pop on empty heap: expected false")
+	}
+}
+
+func TestHeap_HandlesUpdateRemove(t *testing.T) {
+	h := somedata.NewHeapFunc(strings.Compare)
+	handles := h.Heapify([]string{"m", "c", "x", "a", "q"})
+
+	if !h.Update(handles[2], "b") {
+		t.Fatalf("Update: expected true")
+	}
+	if v, ok := h.Remove(handles[0]); !ok || v != "m" {
+		t.Fatalf("Remove: expected m, got %s", v)
+	}
+	if _, ok := h.Remove(handles[0]); ok {
+		t.Fatalf("Remove of removed handle: expected false")
+	}
+	if handles[0].Alive() {
+		t.Fatalf("removed handle must not be alive")
+	}
+
+	got := slices.Collect(h.Drain())
+	if !slices.Equal(got, []string{"a", "b", "c", "q"}) {
+		t.Fatalf("Drain: got %v", got)
+	}
+	if h.Update(handles[1], "z") {
+		t.Fatalf("Update of drained handle: expected false")
+	}
+}
+
+func TestHeap_RandomAgainstSort(t *testing.T) {
+	rnd := rand.New(rand.NewSource(29))
+	h := somedata.NewMinHeap[int]()
+
+	ref := []int{}
+	handles := []*somedata.Handle[int]{}
+	for i := 0; i < 2000; i++ {
+		v := rnd.Intn(1000)
+		handles = append(handles, h.Push(v))
+		ref = append(ref, v)
+	}
+
+	for i := 0; i < 500; i++ {
+		idx := rnd.Intn(len(handles))
+		v := rnd.Intn(1000)
+		h.Update(handles[idx], v)
+		ref[idx] = v
+	}
+
+	slices.Sort(ref)
+	if got := slices.Collect(h.Drain()); !slices.Equal(got, ref) {
+		t.Fatalf("Drain order mismatch")
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	pq := somedata.NewMinPriorityQueue[string, float64]()
+
+	pq.Push("low", 10)
+	mid := pq.Push("mid", 5)
+	pq.Push("high", 1)
+	gone := pq.Push("gone", 0)
+
+	if _, ok := pq.Remove(gone); !ok {
+		t.Fatalf("Remove: expected true")
+	}
+	if !pq.Update(mid, 0.5) {
+		t.Fatalf("Update: expected true")
+	}
+
+	if v, p, ok := pq.Peek(); !ok || v != "mid" || p != 0.5 {
+		t.Fatalf("Peek: expected mid 0.5, got %s %v", v, p)
+	}
+
+	got := []string{}
+	for v := range pq.Drain() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"mid", "high", "low"}) {
+		t.Fatalf("Drain: got %v", got)
+	}
+}
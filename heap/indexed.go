@@ -0,0 +1,182 @@
+package somedata
+
+import (
+	"cmp"
+
+	"golang.org/x/exp/constraints"
+)
+
+type indexedEntry[K comparable, P any] struct {
+	id       K
+	priority P
+}
+
+/*
+indexedPQ - min priority queue addressed by comparable ids.
+Every id is stored at most once and its position is tracked in a map,
+so DecreaseKey, IncreaseKey and Priority lookups need no handles. Fits
+Dijkstra and A* where a vertex priority is relaxed many times.
+*/
+type indexedPQ[K comparable, P any] struct {
+	items []indexedEntry[K, P]
+	index map[K]int // id to position in items
+	cmp   func(a, b P) int
+}
+
+// NewIndexedPQ - creates indexed queue popping the least priorities first
+func NewIndexedPQ[K comparable, P constraints.Ordered]() *indexedPQ[K, P] {
+	return NewIndexedPQFunc[K](cmp.Compare[P])
+}
+
+// NewIndexedPQFunc - creates indexed queue popping the least priorities by cmp first
+func NewIndexedPQFunc[K comparable, P any](cmp func(a, b P) int) *indexedPQ[K, P] {
+	return &indexedPQ[K, P]{
+		index: make(map[K]int),
+		cmp:   cmp,
+	}
+}
+
+// Len - elements count
+func (q *indexedPQ[K, P]) Len() int {
+	return len(q.items)
+}
+
+// Contains - id is stored in queue
+func (q *indexedPQ[K, P]) Contains(id K) bool {
+	_, ok := q.index[id]
+	return ok
+}
+
+// Priority - current priority of id
+func (q *indexedPQ[K, P]) Priority(id K) (P, bool) {
+	i, ok := q.index[id]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return q.items[i].priority, true
+}
+
+// Push - adds id with priority, returns false if id is already stored
+func (q *indexedPQ[K, P]) Push(id K, priority P) bool {
+	if q.Contains(id) {
+		return false
+	}
+
+	q.items = append(q.items, indexedEntry[K, P]{id: id, priority: priority})
+	q.index[id] = len(q.items) - 1
+	q.up(len(q.items) - 1)
+	return true
+}
+
+// Peek - id with the least priority
+func (q *indexedPQ[K, P]) Peek() (K, P, bool) {
+	if len(q.items) == 0 {
+		var (
+			zeroK K
+			zeroP P
+		)
+		return zeroK, zeroP, false
+	}
+	return q.items[0].id, q.items[0].priority, true
+}
+
+// Pop - removes and returns id with the least priority
+func (q *indexedPQ[K, P]) Pop() (K, P, bool) {
+	id, priority, ok := q.Peek()
+	if ok {
+		q.removeAt(0)
+	}
+	return id, priority, ok
+}
+
+// Remove - removes id from queue
+func (q *indexedPQ[K, P]) Remove(id K) bool {
+	i, ok := q.index[id]
+	if ok {
+		q.removeAt(i)
+	}
+	return ok
+}
+
+// DecreaseKey - lowers priority of id, returns false if id is missing
+// or new priority is above the current one
+func (q *indexedPQ[K, P]) DecreaseKey(id K, priority P) bool {
+	i, ok := q.index[id]
+	if !ok || q.cmp(priority, q.items[i].priority) > 0 {
+		return false
+	}
+
+	q.items[i].priority = priority
+	q.up(i)
+	return true
+}
+
+// IncreaseKey - raises priority of id, returns false if id is missing
+// or new priority is below the current one
+func (q *indexedPQ[K, P]) IncreaseKey(id K, priority P) bool {
+	i, ok := q.index[id]
+	if !ok || q.cmp(priority, q.items[i].priority) < 0 {
+		return false
+	}
+
+	q.items[i].priority = priority
+	q.down(i)
+	return true
+}
+
+func (q *indexedPQ[K, P]) removeAt(i int) {
+	last := len(q.items) - 1
+	id := q.items[i].id
+
+	q.swap(i, last)
+	q.items = q.items[:last]
+	delete(q.index, id)
+
+	if i != last {
+		q.up(i)
+		q.down(i)
+	}
+}
+
+func (q *indexedPQ[K, P]) less(i, j int) bool {
+	return q.cmp(q.items[i].priority, q.items[j].priority) < 0
+}
+
+func (q *indexedPQ[K, P]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].id] = i
+	q.index[q.items[j].id] = j
+}
+
+func (q *indexedPQ[K, P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			return
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+func (q *indexedPQ[K, P]) down(i int) {
+	n := len(q.items)
+	for {
+		low := 2*i + 1
+		if low >= n {
+			return
+		}
+
+		least := low
+		if hight := low + 1; hight < n && q.less(hight, low) {
+			least = hight
+		}
+		if !q.less(least, i) {
+			return
+		}
+
+		q.swap(i, least)
+		i = least
+	}
+}
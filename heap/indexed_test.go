@@ -0,0 +1,87 @@
+package somedata_test
+
+import (
+	"testing"
+
+	somedata "github.com/eterline/somedata/heap"
+)
+
+func TestIndexedPQ_Keys(t *testing.T) {
+	q := somedata.NewIndexedPQ[string, int]()
+
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	if q.Push("a", 1) {
+		t.Fatalf("Push of existing id: expected false")
+	}
+
+	if !q.DecreaseKey("c", 1) {
+		t.Fatalf("DecreaseKey(c, 1): expected true")
+	}
+	if q.DecreaseKey("a", 6) {
+		t.Fatalf("DecreaseKey(a, 6): expected false for greater priority")
+	}
+	if !q.IncreaseKey("b", 9) {
+		t.Fatalf("IncreaseKey(b, 9): expected true")
+	}
+	if p, ok := q.Priority("b"); !ok || p != 9 {
+		t.Fatalf("Priority(b): expected 9, got %d", p)
+	}
+
+	order := []string{}
+	for q.Len() != 0 {
+		id, _, _ := q.Pop()
+		order = append(order, id)
+	}
+	if len(order) != 3 || order[0] != "c" || order[1] != "a" || order[2] != "b" {
+		t.Fatalf("Pop order: got %v", order)
+	}
+	if q.Contains("a") {
+		t.Fatalf("Contains after Pop: expected false")
+	}
+}
+
+type edge struct {
+	to     int
+	weight int
+}
+
+func dijkstra(graph map[int][]edge, source int) map[int]int {
+	dist := map[int]int{source: 0}
+	q := somedata.NewIndexedPQ[int, int]()
+	q.Push(source, 0)
+
+	for q.Len() != 0 {
+		v, d, _ := q.Pop()
+		for _, e := range graph[v] {
+			nd := d + e.weight
+			if old, seen := dist[e.to]; seen && old <= nd {
+				continue
+			}
+			dist[e.to] = nd
+			if !q.DecreaseKey(e.to, nd) {
+				q.Push(e.to, nd)
+			}
+		}
+	}
+	return dist
+}
+
+func TestIndexedPQ_Dijkstra(t *testing.T) {
+	graph := map[int][]edge{
+		0: {{1, 4}, {2, 1}},
+		2: {{1, 2}, {3, 5}},
+		1: {{3, 1}},
+		3: {{4, 3}},
+	}
+
+	expected := map[int]int{0: 0, 1: 3, 2: 1, 3: 4, 4: 7}
+	dist := dijkstra(graph, 0)
+
+	for v, d := range expected {
+		if dist[v] != d {
+			t.Fatalf("dist[%d]: expected %d, got %d", v, d, dist[v])
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package somedata
+
+import (
+	"cmp"
+
+	"golang.org/x/exp/constraints"
+)
+
+// pairingGroup - identity of pairing heap nodes set, groups of melded
+// heaps are linked to the receiver group like union-find sets
+type pairingGroup struct {
+	parent *pairingGroup
+}
+
+func (g *pairingGroup) find() *pairingGroup {
+	root := g
+	for root.parent != nil {
+		root = root.parent
+	}
+	for g != root {
+		next := g.parent
+		g.parent = root
+		g = next
+	}
+	return root
+}
+
+// PairingNode - reference to pairing heap element, stays valid until
+// element is removed and follows element when heaps are melded
+type PairingNode[T any] struct {
+	value   T
+	child   *PairingNode[T]
+	sibling *PairingNode[T]
+	prev    *PairingNode[T] // parent for the first child, left sibling otherwise
+	group   *pairingGroup   // nil when removed
+}
+
+// Value - element value
+func (n *PairingNode[T]) Value() T {
+	return n.value
+}
+
+// Alive - element is still stored in heap
+func (n *PairingNode[T]) Alive() bool {
+	return n != nil && n.group != nil
+}
+
+/*
+pairingHeap - meldable heap ordered by comparator.
+Insert, FindMin and Meld are O(1), DecreaseKey is O(1) amortized
+in practice, DeleteMin is O(log n) amortized. Melding moves all
+elements of other heap into the receiver without copying.
+*/
+type pairingHeap[T any] struct {
+	size  int
+	root  *PairingNode[T]
+	group *pairingGroup
+	cmp   func(a, b T) int
+}
+
+// NewPairingHeap - creates pairing heap popping the least values first
+func NewPairingHeap[T constraints.Ordered]() *pairingHeap[T] {
+	return NewPairingHeapFunc(cmp.Compare[T])
+}
+
+// NewPairingHeapFunc - creates pairing heap popping the least values by cmp first,
+// cmp has the same contract as cmp.Compare
+func NewPairingHeapFunc[T any](cmp func(a, b T) int) *pairingHeap[T] {
+	return &pairingHeap[T]{group: &pairingGroup{}, cmp: cmp}
+}
+
+// Len - elements count
+func (h *pairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Insert - adds value
+func (h *pairingHeap[T]) Insert(value T) *PairingNode[T] {
+	n := &PairingNode[T]{value: value, group: h.group}
+	h.root = h.link(h.root, n)
+	h.size++
+	return n
+}
+
+// FindMin - the least value without removing
+func (h *pairingHeap[T]) FindMin() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// DeleteMin - removes and returns the least value
+func (h *pairingHeap[T]) DeleteMin() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.root
+	h.root = h.mergePairs(n.child)
+	h.size--
+	n.detach()
+	return n.value, true
+}
+
+// DecreaseKey - lowers value of element, returns false if node is not
+// stored in heap or value is above the current one
+func (h *pairingHeap[T]) DecreaseKey(n *PairingNode[T], value T) bool {
+	if !h.owns(n) || h.cmp(value, n.value) > 0 {
+		return false
+	}
+
+	n.value = value
+	if n != h.root {
+		n.cut()
+		h.root = h.link(h.root, n)
+	}
+	return true
+}
+
+// Remove - removes element by node
+func (h *pairingHeap[T]) Remove(n *PairingNode[T]) (T, bool) {
+	if !h.owns(n) {
+		var zero T
+		return zero, false
+	}
+	if n == h.root {
+		return h.DeleteMin()
+	}
+
+	n.cut()
+	h.root = h.link(h.root, h.mergePairs(n.child))
+	h.size--
+	n.detach()
+	return n.value, true
+}
+
+// Meld - moves all elements of other into heap, other becomes empty.
+// Nodes of other stay valid and belong to the receiver after meld
+func (h *pairingHeap[T]) Meld(other *pairingHeap[T]) {
+	if other == h || other.root == nil {
+		return
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+
+	other.group.parent = h.group.find()
+	other.group = &pairingGroup{}
+	other.root = nil
+	other.size = 0
+}
+
+func (h *pairingHeap[T]) owns(n *PairingNode[T]) bool {
+	return n.Alive() && n.group.find() == h.group.find()
+}
+
+// link - makes root with greater value the first child of another one
+func (h *pairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs - two-pass merge of siblings list into one tree
+func (h *pairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	var pairs []*PairingNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	var root *PairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	return root
+}
+
+// cut - unlinks node with its subtree from parent and siblings
+func (n *PairingNode[T]) cut() {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+func (n *PairingNode[T]) detach() {
+	n.child, n.sibling, n.prev = nil, nil, nil
+	n.group = nil
+}
@@ -0,0 +1,107 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	somedata "github.com/eterline/somedata/heap"
+)
+
+func TestPairingHeap_DeleteMin(t *testing.T) {
+	h := somedata.NewPairingHeap[int]()
+	rnd := rand.New(rand.NewSource(1))
+
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = rnd.Intn(500)
+		h.Insert(values[i])
+	}
+	slices.Sort(values)
+
+	if min, ok := h.FindMin(); !ok || min != values[0] {
+		t.Fatalf("FindMin: expected %d, got %d", values[0], min)
+	}
+
+	for i, expected := range values {
+		got, ok := h.DeleteMin()
+		if !ok || got != expected {
+			t.Fatalf("DeleteMin #%d: expected %d, got %d", i, expected, got)
+		}
+	}
+	if _, ok := h.DeleteMin(); ok || h.Len() != 0 {
+		t.Fatalf("expected empty heap")
+	}
+}
+
+func TestPairingHeap_DecreaseKeyAndRemove(t *testing.T) {
+	h := somedata.NewPairingHeap[int]()
+
+	nodes := make([]*somedata.PairingNode[int], 10)
+	for i := range nodes {
+		nodes[i] = h.Insert(i * 10)
+	}
+	h.DeleteMin()
+	h.Insert(45)
+
+	if !h.DecreaseKey(nodes[7], 5) {
+		t.Fatalf("DecreaseKey(70 -> 5): expected true")
+	}
+	if h.DecreaseKey(nodes[3], 31) {
+		t.Fatalf("DecreaseKey(30 -> 31): expected false for greater value")
+	}
+	if h.DecreaseKey(nodes[0], -1) {
+		t.Fatalf("DecreaseKey on removed node: expected false")
+	}
+	if v, ok := h.Remove(nodes[5]); !ok || v != 50 {
+		t.Fatalf("Remove: expected 50, got %d", v)
+	}
+	if nodes[5].Alive() {
+		t.Fatalf("removed node is alive")
+	}
+
+	got := []int{}
+	for h.Len() != 0 {
+		v, _ := h.DeleteMin()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{5, 10, 20, 30, 40, 45, 60, 80, 90}) {
+		t.Fatalf("order: got %v", got)
+	}
+}
+
+func TestPairingHeap_Meld(t *testing.T) {
+	a := somedata.NewPairingHeap[int]()
+	b := somedata.NewPairingHeap[int]()
+
+	for i := 0; i < 10; i += 2 {
+		a.Insert(i)
+	}
+	nodes := []*somedata.PairingNode[int]{}
+	for i := 1; i < 10; i += 2 {
+		nodes = append(nodes, b.Insert(i))
+	}
+
+	a.Meld(b)
+	if a.Len() != 10 || b.Len() != 0 {
+		t.Fatalf("Meld: expected sizes 10/0, got %d/%d", a.Len(), b.Len())
+	}
+	if b.DecreaseKey(nodes[4], -1) {
+		t.Fatalf("DecreaseKey through melded source: expected false")
+	}
+	if !a.DecreaseKey(nodes[4], -1) {
+		t.Fatalf("DecreaseKey of melded node: expected true")
+	}
+
+	b.Insert(100)
+	a.Meld(b)
+
+	got := []int{}
+	for a.Len() != 0 {
+		v, _ := a.DeleteMin()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 100}) {
+		t.Fatalf("order: got %v", got)
+	}
+}
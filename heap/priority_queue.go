@@ -0,0 +1,89 @@
+package somedata
+
+import (
+	"cmp"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// PQEntry - priority queue element
+type PQEntry[V any, P any] struct {
+	Value    V
+	Priority P
+}
+
+/*
+priorityQueue - values ordered by separate priorities.
+Built on top of heap, so handles returned by Push allow
+to change priority or remove element in O(log n).
+*/
+type priorityQueue[V any, P any] struct {
+	h *heap[PQEntry[V, P]]
+}
+
+// NewMinPriorityQueue - creates queue popping the least priorities first
+func NewMinPriorityQueue[V any, P constraints.Ordered]() *priorityQueue[V, P] {
+	return NewPriorityQueueFunc[V](cmp.Compare[P])
+}
+
+// NewMaxPriorityQueue - creates queue popping the greatest priorities first
+func NewMaxPriorityQueue[V any, P constraints.Ordered]() *priorityQueue[V, P] {
+	return NewPriorityQueueFunc[V](func(a, b P) int { return cmp.Compare(b, a) })
+}
+
+// NewPriorityQueueFunc - creates queue popping the least priorities by cmp first
+func NewPriorityQueueFunc[V any, P any](cmp func(a, b P) int) *priorityQueue[V, P] {
+	return &priorityQueue[V, P]{
+		h: NewHeapFunc(func(a, b PQEntry[V, P]) int {
+			return cmp(a.Priority, b.Priority)
+		}),
+	}
+}
+
+// Len - elements count
+func (pq *priorityQueue[V, P]) Len() int {
+	return pq.h.Len()
+}
+
+// Push - adds value with priority
+func (pq *priorityQueue[V, P]) Push(value V, priority P) *Handle[PQEntry[V, P]] {
+	return pq.h.Push(PQEntry[V, P]{Value: value, Priority: priority})
+}
+
+// Peek - the first value in order with its priority
+func (pq *priorityQueue[V, P]) Peek() (V, P, bool) {
+	e, ok := pq.h.Peek()
+	return e.Value, e.Priority, ok
+}
+
+// Pop - removes and returns the first value in order with its priority
+func (pq *priorityQueue[V, P]) Pop() (V, P, bool) {
+	e, ok := pq.h.Pop()
+	return e.Value, e.Priority, ok
+}
+
+// Update - changes priority of element
+func (pq *priorityQueue[V, P]) Update(it *Handle[PQEntry[V, P]], priority P) bool {
+	if !pq.h.owns(it) {
+		return false
+	}
+	return pq.h.Update(it, PQEntry[V, P]{Value: it.value.Value, Priority: priority})
+}
+
+// Remove - removes element by handle
+func (pq *priorityQueue[V, P]) Remove(it *Handle[PQEntry[V, P]]) (V, bool) {
+	e, ok := pq.h.Remove(it)
+	return e.Value, ok
+}
+
+// Drain - iterator popping values with priorities in order
+func (pq *priorityQueue[V, P]) Drain() iter.Seq2[V, P] {
+	return func(yield func(V, P) bool) {
+		for e := range pq.h.Drain() {
+			if !yield(e.Value, e.Priority) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package somedata
+
+import "github.com/eterline/somedata"
+
+func addOp[T Numeric](a, b T) T { return a + b }
+func subOp[T Numeric](a, b T) T { return a - b }
+func mulOp[T Numeric](a, b T) T { return a * b }
+
+// broadcastShape - NumPy broadcasting of two shapes aligned by trailing axes,
+// sizes of every axis must be equal or one of them must be 1
+func broadcastShape(a, b []int) ([]int, bool) {
+	rank := max(len(a), len(b))
+	out := make([]int, rank)
+
+	for i := 1; i <= rank; i++ {
+		da, db := 1, 1
+		if i <= len(a) {
+			da = a[len(a)-i]
+		}
+		if i <= len(b) {
+			db = b[len(b)-i]
+		}
+
+		switch {
+		case da == db, db == 1:
+			out[rank-i] = da
+		case da == 1:
+			out[rank-i] = db
+		default:
+			return nil, false
+		}
+	}
+	return out, true
+}
+
+// broadcastStrides - strides of view aligned to out rank,
+// broadcasted axes get zero stride so their elements are reused
+func broadcastStrides[T Numeric](v *matrixView[T], out []int) []int {
+	strides := make([]int, len(out))
+	shift := len(out) - len(v.shape)
+
+	for i, s := range v.shape {
+		if s != 1 {
+			strides[shift+i] = v.strides[i]
+		}
+	}
+	return strides
+}
+
+// broadcast - applies op to elements of a and b with broadcasted shapes,
+// operands are read in place by their strides without repeating elements
+func broadcast[T Numeric](a, b Matrix[T], op func(a, b T) T) (Matrix[T], error) {
+	av, bv := asView(a), asView(b)
+
+	shape, ok := broadcastShape(av.shape, bv.shape)
+	if !ok {
+		return nil, somedata.ErrMatUnequalShapes(len(av.shape))
+	}
+
+	var (
+		aStr   = broadcastStrides(av, shape)
+		bStr   = broadcastStrides(bv, shape)
+		out    = make([]T, shapeSize(shape))
+		coords = make([]int, len(shape))
+		last   = len(shape) - 1
+		n      = shape[last]
+	)
+
+	ai, bi := av.offset, bv.offset
+	for o := 0; o < len(out); o += n {
+		row := out[o : o+n]
+		for j := range row {
+			row[j] = op(av.arr[ai+j*aStr[last]], bv.arr[bi+j*bStr[last]])
+		}
+
+		for axis := last - 1; axis >= 0; axis-- {
+			coords[axis]++
+			ai += aStr[axis]
+			bi += bStr[axis]
+			if coords[axis] < shape[axis] {
+				break
+			}
+			ai -= coords[axis] * aStr[axis]
+			bi -= coords[axis] * bStr[axis]
+			coords[axis] = 0
+		}
+	}
+
+	return wrapFlat(out, shape), nil
+}
@@ -0,0 +1,157 @@
+package somedata_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	root "github.com/eterline/somedata"
+	somedata "github.com/eterline/somedata/matrix"
+)
+
+// vector of length height is aligned with the last axis of [width, height]
+// and adds one value per row, so it acts as a column
+func TestBroadcastColumnVector(t *testing.T) {
+	m := newRangeMatrix2(3, 4)
+	col := somedata.NewMatrixN[int](4)
+	copy(col.Flatten(), []int{10, 20, 30, 40})
+
+	sum, err := m.Add(col)
+	if err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	if !slices.Equal(sum.Shape(), []int{3, 4}) {
+		t.Fatalf("expected shape [3,4], got %v", sum.Shape())
+	}
+	expected := []int{10, 21, 32, 43, 14, 25, 36, 47, 18, 29, 40, 51}
+	if !slices.Equal(sum.Flatten(), expected) {
+		t.Fatalf("Add column vector: got %v", sum.Flatten())
+	}
+	if sum.Get(2, 3) != m.Get(2, 3)+40 {
+		t.Fatalf("Add column vector: row 3 is not shifted by 40")
+	}
+
+	diff, err := col.Sub(m)
+	if err != nil || diff.Get(2, 3) != 40-11 {
+		t.Fatalf("Sub from column vector: got %v", err)
+	}
+
+	// per-column bias has one row, a vector of length width does not broadcast
+	if _, err := m.Add(somedata.NewMatrixN[int](3)); !errors.Is(err, root.ErrMatUnequalShapes(2)) {
+		t.Fatalf("Add vector of width length: expected ErrMatUnequalShapes, got %v", err)
+	}
+}
+
+func TestBroadcastRowAndScalar(t *testing.T) {
+	m := newRangeMatrix2(2, 3)
+
+	row := somedata.NewMatrix2[int](2, 1)
+	row.Set(1, 0, 0)
+	row.Set(-1, 1, 0)
+	prod, err := m.MulHadamard(row)
+	if err != nil || !slices.Equal(prod.Flatten(), []int{0, 1, 2, -3, -4, -5}) {
+		t.Fatalf("MulHadamard by row: got %v (%v)", prod, err)
+	}
+	for y := 0; y < 3; y++ {
+		if prod.Get(1, y) != -m.Get(1, y) {
+			t.Fatalf("MulHadamard by row: column 1 is not negated at row %d", y)
+		}
+	}
+
+	scalar := somedata.NewMatrixN[int](1)
+	scalar.Set(3, 0)
+	scaled, err := m.MulHadamard(scalar)
+	if err != nil || !scaled.Equals(m.Scale(3)) {
+		t.Fatalf("MulHadamard by scalar: got %v", err)
+	}
+
+	// outer sum of row [2,1] and column [1,3]
+	col := somedata.NewMatrix2[int](1, 3)
+	copy(col.Flatten(), []int{0, 10, 20})
+	outer, err := row.Add(col)
+	if err != nil || !slices.Equal(outer.Flatten(), []int{1, 11, 21, -1, 9, 19}) {
+		t.Fatalf("outer Add: got %v (%v)", outer, err)
+	}
+}
+
+func TestBroadcastChannels(t *testing.T) {
+	img := somedata.NewMatrix3[float64](2, 2, 3)
+	for i := range img.Flatten() {
+		img.Flatten()[i] = 1
+	}
+
+	gain := somedata.NewMatrixN[float64](3)
+	copy(gain.Flatten(), []float64{0.5, 1, 2})
+
+	out, err := img.MulHadamard(gain)
+	if err != nil {
+		t.Fatalf("MulHadamard() returned error: %v", err)
+	}
+	if out.Rank() != 3 || out.Get(1, 1, 0) != 0.5 || out.Get(0, 1, 2) != 2 {
+		t.Fatalf("per-channel scaling: got %v", out.Flatten())
+	}
+
+	_, err = img.Add(somedata.NewMatrixN[float64](2))
+	if !errors.Is(err, root.ErrMatUnequalShapes(3)) {
+		t.Fatalf("Add of incompatible shapes: expected ErrMatUnequalShapes, got %v", err)
+	}
+}
+
+func TestBroadcastView(t *testing.T) {
+	m := newRangeMatrix2(2, 3)
+	col := somedata.NewMatrixN[int](2)
+	copy(col.Flatten(), []int{100, 200})
+
+	sum, err := m.Transpose().Add(col)
+	if err != nil || !slices.Equal(sum.Flatten(), []int{100, 203, 101, 204, 102, 205}) {
+		t.Fatalf("Add to transposed view: got %v (%v)", sum, err)
+	}
+}
+
+func TestBroadcastMixedLayouts(t *testing.T) {
+	m3 := somedata.NewMatrix3[int](2, 3, 4)
+	mn := somedata.NewMatrixN[int](2, 3, 4)
+	for i := range m3.Flatten() {
+		m3.Flatten()[i] = i
+		mn.Flatten()[i] = 100 * i
+	}
+
+	sum, err := mn.Add(m3)
+	if err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 3; y++ {
+			for z := 0; z < 4; z++ {
+				if sum.Get(x, y, z) != mn.Get(x, y, z)+m3.Get(x, y, z) {
+					t.Fatalf("Add mismatch at (%d, %d, %d)", x, y, z)
+				}
+			}
+		}
+	}
+
+	diff, err := m3.Sub(mn)
+	if err != nil || diff.Get(1, 2, 3) != m3.Get(1, 2, 3)-mn.Get(1, 2, 3) {
+		t.Fatalf("Sub of mixed layouts: got %v", err)
+	}
+}
+
+func TestBroadcastMatrix3Equals(t *testing.T) {
+	m3 := somedata.NewMatrix3[int](2, 3, 4)
+	for i := range m3.Flatten() {
+		m3.Flatten()[i] = i
+	}
+
+	sum, err := m3.Add(somedata.NewMatrixN[int](1, 1, 4))
+	if err != nil {
+		t.Fatalf("Add() returned error: %v", err)
+	}
+	if !m3.Equals(sum) || !sum.Equals(m3) {
+		t.Fatalf("matrix3 plus zeros is not equal to itself")
+	}
+
+	sum.Set(-1, 1, 2, 3)
+	if m3.Equals(sum) || sum.Equals(m3) {
+		t.Fatalf("changed broadcast result is equal to matrix3")
+	}
+}
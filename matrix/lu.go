@@ -0,0 +1,242 @@
+package somedata
+
+import (
+	"github.com/eterline/somedata"
+	"golang.org/x/exp/constraints"
+)
+
+/*
+lu - LU decomposition with partial pivoting P·A = L·U of square matrix.
+L has unit diagonal and is stored below diagonal of arr, U is stored
+on and above it. Unlike matrix2, arr keeps factors row by row so
+elimination walks rows sequentially. Matrix is singular when pivot
+is within n·eps of zero relative to the largest element of its source
+row, so badly scaled rows do not hide each other.
+*/
+type lu[T constraints.Float] struct {
+	n        int
+	arr      []T   // combined L and U factors
+	perm     []int // perm[i] - row of source matrix placed at row i
+	sign     T     // permutation parity, 1 or -1
+	singular bool
+}
+
+// NewLU - decomposes square 2D matrix, source matrix is not modified
+func NewLU[T constraints.Float](m Matrix[T]) (*lu[T], error) {
+	shape := m.Shape()
+	if len(shape) != 2 || shape[0] != shape[1] {
+		return nil, somedata.ErrMatNotSquare(shape)
+	}
+
+	n := shape[0]
+	d := &lu[T]{
+		n:    n,
+		arr:  make([]T, n*n),
+		perm: make([]int, n),
+		sign: 1,
+	}
+	var (
+		flat  = m.Flatten()
+		scale = make([]T, n) // scale[y] - the largest element of source row y
+	)
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			d.arr[y*n+x] = flat[x*n+y]
+			scale[y] = max(scale[y], abs(flat[x*n+y]))
+		}
+	}
+	tolerance := T(n) * epsilon[T]()
+	for i := range d.perm {
+		d.perm[i] = i
+	}
+
+	for k := 0; k < n; k++ {
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if abs(d.arr[i*n+k]) > abs(d.arr[pivot*n+k]) {
+				pivot = i
+			}
+		}
+
+		if pivot != k {
+			rowK := d.arr[k*n : (k+1)*n]
+			rowP := d.arr[pivot*n : (pivot+1)*n]
+			for j := range rowK {
+				rowK[j], rowP[j] = rowP[j], rowK[j]
+			}
+			d.perm[k], d.perm[pivot] = d.perm[pivot], d.perm[k]
+			d.sign = -d.sign
+		}
+
+		diag := d.arr[k*n+k]
+		if abs(diag) <= tolerance*scale[d.perm[k]] {
+			d.singular = true
+		}
+		if diag == 0 {
+			continue
+		}
+
+		rowK := d.arr[k*n+k+1 : (k+1)*n]
+		for i := k + 1; i < n; i++ {
+			factor := d.arr[i*n+k] / diag
+			d.arr[i*n+k] = factor
+			if factor == 0 {
+				continue
+			}
+
+			rowI := d.arr[i*n+k+1 : (i+1)*n]
+			rowI = rowI[:len(rowK)]
+			for j, u := range rowK {
+				rowI[j] -= factor * u
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// epsilon - difference between 1 and the next representable value of T
+func epsilon[T constraints.Float]() T {
+	eps := T(1)
+	for one := T(1); one+eps/2 != one; {
+		eps /= 2
+	}
+	return eps
+}
+
+func abs[T constraints.Float](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Singular - matrix has no inverse
+func (d *lu[T]) Singular() bool {
+	return d.singular
+}
+
+// Pivot - permutation of rows, i row of P·A is perm[i] row of A
+func (d *lu[T]) Pivot() []int {
+	out := make([]int, d.n)
+	copy(out, d.perm)
+	return out
+}
+
+// L - lower triangular factor with unit diagonal
+func (d *lu[T]) L() Matrix[T] {
+	l := newMatrix2[T](d.n, d.n)
+	for y := 0; y < d.n; y++ {
+		for x := 0; x < y; x++ {
+			l.arr[x*d.n+y] = d.arr[y*d.n+x]
+		}
+		l.arr[y*d.n+y] = 1
+	}
+	return l
+}
+
+// U - upper triangular factor
+func (d *lu[T]) U() Matrix[T] {
+	u := newMatrix2[T](d.n, d.n)
+	for y := 0; y < d.n; y++ {
+		for x := y; x < d.n; x++ {
+			u.arr[x*d.n+y] = d.arr[y*d.n+x]
+		}
+	}
+	return u
+}
+
+// Det - determinant as product of U diagonal and permutation parity
+func (d *lu[T]) Det() T {
+	det := d.sign
+	for i := 0; i < d.n; i++ {
+		det *= d.arr[i*d.n+i]
+	}
+	return det
+}
+
+// Solve - solution x of A·x = b
+func (d *lu[T]) Solve(b []T) ([]T, error) {
+	if len(b) != d.n {
+		return nil, somedata.ErrMatVecLength(2, d.n, len(b))
+	}
+	if d.singular {
+		return nil, somedata.ErrMatSingular
+	}
+
+	x := make([]T, d.n)
+	for i, p := range d.perm {
+		x[i] = b[p]
+	}
+	d.solveInPlace(x)
+	return x, nil
+}
+
+// solveInPlace - forward substitution by L and back substitution by U
+func (d *lu[T]) solveInPlace(x []T) {
+	n := d.n
+	for i := 1; i < n; i++ {
+		row := d.arr[i*n : i*n+i]
+		for j, l := range row {
+			x[i] -= l * x[j]
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		row := d.arr[i*n : (i+1)*n]
+		for j := i + 1; j < n; j++ {
+			x[i] -= row[j] * x[j]
+		}
+		x[i] /= row[i]
+	}
+}
+
+// Inverse - inverse matrix, solves A·x = e for every unit column e
+func (d *lu[T]) Inverse() (Matrix[T], error) {
+	if d.singular {
+		return nil, somedata.ErrMatSingular
+	}
+
+	n := d.n
+	inv := newMatrix2[T](n, n)
+	col := make([]T, n)
+
+	for j := 0; j < n; j++ {
+		for i, p := range d.perm {
+			col[i] = 0
+			if p == j {
+				col[i] = 1
+			}
+		}
+		d.solveInPlace(col)
+		copy(inv.arr[j*n:(j+1)*n], col)
+	}
+	return inv, nil
+}
+
+// Det - determinant of square 2D matrix
+func Det[T constraints.Float](m Matrix[T]) (T, error) {
+	d, err := NewLU(m)
+	if err != nil {
+		return 0, err
+	}
+	return d.Det(), nil
+}
+
+// Inverse - inverse of square 2D matrix
+func Inverse[T constraints.Float](m Matrix[T]) (Matrix[T], error) {
+	d, err := NewLU(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Inverse()
+}
+
+// Solve - solution x of linear system m·x = b
+func Solve[T constraints.Float](m Matrix[T], b []T) ([]T, error) {
+	d, err := NewLU(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Solve(b)
+}
@@ -0,0 +1,159 @@
+package somedata_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	root "github.com/eterline/somedata"
+	somedata "github.com/eterline/somedata/matrix"
+)
+
+func almostEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLU_Factors(t *testing.T) {
+	a := newRowsMatrix2([]float64{2, 1, 1}, []float64{4, -6, 0}, []float64{-2, 7, 2})
+
+	d, err := somedata.NewLU(a)
+	if err != nil {
+		t.Fatalf("NewLU() returned error: %v", err)
+	}
+
+	lu, err := d.L().MatMul(d.U())
+	if err != nil {
+		t.Fatalf("L·U returned error: %v", err)
+	}
+
+	perm := d.Pivot()
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			if math.Abs(lu.Get(x, y)-a.Get(x, perm[y])) > 1e-12 {
+				t.Fatalf("L·U mismatch with P·A at (%d, %d)", x, y)
+			}
+		}
+	}
+
+	if det := d.Det(); math.Abs(det-(-16)) > 1e-9 {
+		t.Fatalf("Det: expected -16, got %v", det)
+	}
+}
+
+func TestLU_SolveInverse(t *testing.T) {
+	a := newRowsMatrix2([]float64{2, 1, 1}, []float64{4, -6, 0}, []float64{-2, 7, 2})
+
+	x, err := somedata.Solve(a, []float64{5, -2, 9})
+	if err != nil {
+		t.Fatalf("Solve() returned error: %v", err)
+	}
+	if !almostEqual(x, []float64{1, 1, 2}) {
+		t.Fatalf("Solve: got %v, want [1 1 2]", x)
+	}
+
+	inv, err := somedata.Inverse(a)
+	if err != nil {
+		t.Fatalf("Inverse() returned error: %v", err)
+	}
+	id, _ := a.MatMul(inv)
+	if !almostEqual(id.Flatten(), []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}) {
+		t.Fatalf("A·A^-1 is not identity: %v", id.Flatten())
+	}
+}
+
+func TestLU_Random(t *testing.T) {
+	const n = 40
+	rnd := rand.New(rand.NewSource(1))
+
+	a := somedata.NewMatrix2[float64](n, n)
+	want := make([]float64, n)
+	for i := range a.Flatten() {
+		a.Flatten()[i] = rnd.Float64()*2 - 1
+	}
+	for i := range want {
+		want[i] = rnd.Float64()
+	}
+
+	b, _ := a.MulVec(want)
+	x, err := somedata.Solve[float64](a, b)
+	if err != nil || !almostEqual(x, want) {
+		t.Fatalf("Solve of random system failed: %v", err)
+	}
+}
+
+func TestLU_Singular(t *testing.T) {
+	// rounding leaves u33 = 6.66e-16 instead of exact zero
+	nearly := newRowsMatrix2([]float64{1, 2, 3}, []float64{4, 5, 6}, []float64{7, 8, 9})
+	d, err := somedata.NewLU(nearly)
+	if err != nil || !d.Singular() || math.Abs(d.Det()) > 1e-12 {
+		t.Fatalf("NewLU of rank 2 matrix: expected singular with near zero Det, got %v (%v)", d.Det(), err)
+	}
+	if _, err := d.Inverse(); !errors.Is(err, root.ErrMatSingular) {
+		t.Fatalf("Inverse of rank 2 matrix: expected ErrMatSingular, got %v", err)
+	}
+
+	a := newRowsMatrix2([]float64{1, 2, 3}, []float64{2, 4, 6}, []float64{1, 0, 1})
+
+	if det, err := somedata.Det(a); err != nil || det != 0 {
+		t.Fatalf("Det of singular: expected 0, got %v (%v)", det, err)
+	}
+	if _, err := somedata.Inverse(a); !errors.Is(err, root.ErrMatSingular) {
+		t.Fatalf("Inverse of singular: expected ErrMatSingular, got %v", err)
+	}
+	if _, err := somedata.Solve(a, []float64{1, 2, 3}); !errors.Is(err, root.ErrMatSingular) {
+		t.Fatalf("Solve of singular: expected ErrMatSingular, got %v", err)
+	}
+
+	if _, err := somedata.NewLU[float64](somedata.NewMatrix2[float64](2, 3)); err == nil {
+		t.Fatalf("NewLU of non-square: expected error")
+	}
+}
+
+func TestLU_BadlyScaled(t *testing.T) {
+	cases := []struct {
+		a   somedata.Matrix[float64]
+		det float64
+	}{
+		{newRowsMatrix2([]float64{1e10, 0}, []float64{0, 1e-10}), 1},
+		{newRowsMatrix2([]float64{1e20, 0}, []float64{0, 1}), 1e20},
+		{newRowsMatrix2([]float64{0, 1e-10}, []float64{1e10, 1e-10}), -1},
+	}
+
+	for _, c := range cases {
+		d, err := somedata.NewLU(c.a)
+		if err != nil || d.Singular() {
+			t.Fatalf("NewLU of %v: expected regular matrix (%v)", c.a.Flatten(), err)
+		}
+		if det := d.Det(); math.Abs(det-c.det) > 1e-9*math.Abs(c.det) {
+			t.Fatalf("Det of %v: expected %v, got %v", c.a.Flatten(), c.det, det)
+		}
+
+		inv, err := d.Inverse()
+		if err != nil {
+			t.Fatalf("Inverse of %v returned error: %v", c.a.Flatten(), err)
+		}
+		id, _ := c.a.MatMul(inv)
+		if !almostEqual(id.Flatten(), []float64{1, 0, 0, 1}) {
+			t.Fatalf("A·A^-1 of %v is not identity: %v", c.a.Flatten(), id.Flatten())
+		}
+	}
+}
+
+func TestLU_Float32(t *testing.T) {
+	a := somedata.NewMatrix2[float32](2, 2)
+	copy(a.Flatten(), []float32{4, 3, 6, 3})
+
+	det, err := somedata.Det[float32](a)
+	if err != nil || math.Abs(float64(det)-(-6)) > 1e-5 {
+		t.Fatalf("Det float32: expected -6, got %v (%v)", det, err)
+	}
+}
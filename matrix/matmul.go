@@ -0,0 +1,101 @@
+package somedata
+
+import (
+	"runtime"
+	"sync"
+)
+
+const (
+	// matMulBlock - tile side, three float64 tiles fit into L2 cache
+	matMulBlock = 64
+	// matMulParallel - multiply-add count since product is split between goroutines
+	matMulParallel = 1 << 18
+)
+
+// matMul - c += a·b for row-major a (n×k), b (k×p) and c (n×p).
+// Row tiles are independent and processed by parallel workers.
+// Matrices are stored column by column, which is row-major store
+// of transposition, so C = A·B is computed as Cᵀ = Bᵀ·Aᵀ
+// by passing operands in reverse order
+func matMul[T Numeric](a, b, c []T, n, k, p int) {
+	tiles := (n + matMulBlock - 1) / matMulBlock
+	workers := min(runtime.GOMAXPROCS(0), tiles)
+	if n*k*p < matMulParallel || workers < 2 {
+		for i0 := 0; i0 < n; i0 += matMulBlock {
+			matMulTile(a, b, c, i0, min(i0+matMulBlock, n), k, p)
+		}
+		return
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next = make(chan int, tiles)
+	)
+	for i0 := 0; i0 < n; i0 += matMulBlock {
+		next <- i0
+	}
+	close(next)
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i0 := range next {
+				matMulTile(a, b, c, i0, min(i0+matMulBlock, n), k, p)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// matMulTile - computes rows [i0, i1) of product tile by tile,
+// inner loop walks b and c rows sequentially
+func matMulTile[T Numeric](a, b, c []T, i0, i1, k, p int) {
+	for l0 := 0; l0 < k; l0 += matMulBlock {
+		l1 := min(l0+matMulBlock, k)
+
+		for j0 := 0; j0 < p; j0 += matMulBlock {
+			j1 := min(j0+matMulBlock, p)
+
+			for i := i0; i < i1; i++ {
+				cRow := c[i*p+j0 : i*p+j1]
+				aRow := a[i*k+l0 : i*k+l1]
+
+				// four rows of b per pass cut loads and stores of c row
+				l := 0
+				for ; l+4 <= len(aRow); l += 4 {
+					a0, a1, a2, a3 := aRow[l], aRow[l+1], aRow[l+2], aRow[l+3]
+					off := (l0+l)*p + j0
+					b0 := b[off : off+len(cRow)]
+					b1 := b[off+p : off+p+len(cRow)]
+					b2 := b[off+2*p : off+2*p+len(cRow)]
+					b3 := b[off+3*p : off+3*p+len(cRow)]
+					for j := range cRow {
+						cRow[j] += a0*b0[j] + a1*b1[j] + a2*b2[j] + a3*b3[j]
+					}
+				}
+				for ; l < len(aRow); l++ {
+					av := aRow[l]
+					bRow := b[(l0+l)*p+j0 : (l0+l)*p+j1]
+					bRow = bRow[:len(cRow)]
+					for j, bv := range bRow {
+						cRow[j] += av * bv
+					}
+				}
+			}
+		}
+	}
+}
+
+// matVec - out += A·v for A stored column by column
+// as w columns of len(out) elements, len(v) must be w
+func matVec[T Numeric](a, v, out []T, w int) {
+	h := len(out)
+	for x, k := range v[:w] {
+		col := a[x*h : (x+1)*h]
+		col = col[:len(out)]
+		for y, e := range col {
+			out[y] += k * e
+		}
+	}
+}
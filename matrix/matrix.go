@@ -1,6 +1,7 @@
 package somedata
 
 import (
+	"github.com/eterline/somedata"
 	"golang.org/x/exp/constraints"
 )
 
@@ -33,14 +34,26 @@ type Matrix[T Numeric] interface {
 	// Zero - set all matrix values by default value in type
 	Zero()
 
+	// Add, Sub, MulHadamard - element-wise operations, shapes are
+	// broadcast like in NumPy when they are not equal,
+	// ErrMatUnequalShapes is returned when broadcasting fails.
+	// Shapes are aligned by trailing axes of Shape, so for 2D matrix
+	// [width, height] vector of length height adds one value per row,
+	// bias per column must be NewMatrix2(width, 1)
 	Add(m Matrix[T]) (Matrix[T], error)
 	Sub(m Matrix[T]) (Matrix[T], error)
 	MulHadamard(m Matrix[T]) (Matrix[T], error)
+
+	// MatMul - matrix product, defined for 2D matrices with
+	// width equal to height of m
+	MatMul(m Matrix[T]) (Matrix[T], error)
+	// MulVec - matrix-vector product, defined for 2D matrices
+	MulVec(v []T) ([]T, error)
 }
 
 func coords2Idx(shape, coords []int) int {
 	if len(shape) != len(coords) {
-		panic("matrix: dimension mismatch")
+		panic(somedata.ErrMatDimCoordMismatch(len(shape)))
 	}
 
 	index := 0
@@ -48,7 +61,7 @@ func coords2Idx(shape, coords []int) int {
 
 	for i := len(shape) - 1; i >= 0; i-- {
 		if coords[i] < 0 || coords[i] >= shape[i] {
-			panic("matrix: index out of range")
+			panic(somedata.ErrMatOutCoords(len(shape)))
 		}
 		index += coords[i] * stride
 		stride *= shape[i]
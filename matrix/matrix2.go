@@ -9,9 +9,11 @@ import (
 )
 
 /*
-matrix2 - default 2D matrix
+matrix2 - default 2D matrix.
+Coords are (x, y): column x in [0, width) and row y in [0, height),
+elements are stored column by column.
 
-for example:
+for example NewMatrix2(5, 3):
 
 	[1 2 3 4 5]
 	[2 5 6 7 2]
@@ -25,6 +27,10 @@ type matrix2[T Numeric] struct {
 
 // NewMatrix2 - creates new 2D matrix
 func NewMatrix2[T Numeric](width, height int) Matrix[T] {
+	return newMatrix2[T](width, height)
+}
+
+func newMatrix2[T Numeric](width, height int) *matrix2[T] {
 	if width < 1 || height < 1 {
 		panic(somedata.ErrMatNegativeCoords(2))
 	}
@@ -36,13 +42,13 @@ func NewMatrix2[T Numeric](width, height int) Matrix[T] {
 	}
 }
 
-// index=y⋅width+x
+// index=x⋅height+y
 func (mt *matrix2[T]) coords2idx(w, h int) int {
-	if w >= mt.width || h >= mt.height {
+	if w < 0 || h < 0 || w >= mt.width || h >= mt.height {
 		panic(somedata.ErrMatOutCoords(mt.Rank()))
 	}
 
-	return w*mt.width + h
+	return w*mt.height + h
 }
 
 func (mt *matrix2[T]) Rank() int {
@@ -113,6 +119,10 @@ func (mt *matrix2[T]) Scale(k T) Matrix[T] {
 }
 
 func (mt *matrix2[T]) Equals(m Matrix[T]) bool {
+	if !rowMajor(m) {
+		return slices.Equal(mt.arr, asView(m).flatCopy())
+	}
+
 	flt := m.Flatten()
 	return slices.Equal(mt.arr, flt)
 }
@@ -126,7 +136,7 @@ func (mt *matrix2[T]) Zero() {
 
 func (mt *matrix2[T]) Add(m Matrix[T]) (Matrix[T], error) {
 	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+		return broadcast(mt, m, addOp[T])
 	}
 
 	var (
@@ -147,7 +157,7 @@ func (mt *matrix2[T]) Add(m Matrix[T]) (Matrix[T], error) {
 
 func (mt *matrix2[T]) Sub(m Matrix[T]) (Matrix[T], error) {
 	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+		return broadcast(mt, m, subOp[T])
 	}
 
 	var (
@@ -168,7 +178,7 @@ func (mt *matrix2[T]) Sub(m Matrix[T]) (Matrix[T], error) {
 
 func (mt *matrix2[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
 	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+		return broadcast(mt, m, mulOp[T])
 	}
 
 	var (
@@ -186,3 +196,60 @@ func (mt *matrix2[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
 
 	return newMt, nil
 }
+
+// MatMul - matrix product, m height must be equal to matrix width
+func (mt *matrix2[T]) MatMul(m Matrix[T]) (Matrix[T], error) {
+	shape := m.Shape()
+	if len(shape) != 2 || shape[1] != mt.width {
+		return nil, somedata.ErrMatMulShapes(mt.Shape(), shape)
+	}
+
+	newMt := &matrix2[T]{
+		width:  shape[0],
+		height: mt.height,
+		arr:    make([]T, shape[0]*mt.height),
+	}
+
+	matMul(m.Flatten(), mt.arr, newMt.arr, shape[0], mt.width, mt.height)
+	return newMt, nil
+}
+
+// MulVec - matrix-vector product, v length must be equal to matrix width
+func (mt *matrix2[T]) MulVec(v []T) ([]T, error) {
+	if len(v) != mt.width {
+		return nil, somedata.ErrMatVecLength(mt.Rank(), mt.width, len(v))
+	}
+
+	out := make([]T, mt.height)
+	matVec(mt.arr, v, out, mt.width)
+	return out, nil
+}
+
+// view - contiguous view sharing data store
+func (mt *matrix2[T]) view() *matrixView[T] {
+	return newView(mt.arr, mt.Shape())
+}
+
+func (mt *matrix2[T]) Transpose(axes ...int) View[T] {
+	return mt.view().Transpose(axes...)
+}
+
+func (mt *matrix2[T]) Reshape(shape ...int) (View[T], error) {
+	return mt.view().Reshape(shape...)
+}
+
+func (mt *matrix2[T]) Slice(lo, hi []int) View[T] {
+	return mt.view().Slice(lo, hi)
+}
+
+func (mt *matrix2[T]) Row(i int) View[T] {
+	return mt.view().Row(i)
+}
+
+func (mt *matrix2[T]) Col(i int) View[T] {
+	return mt.view().Col(i)
+}
+
+func (mt *matrix2[T]) Materialize() Matrix[T] {
+	return mt.clone()
+}
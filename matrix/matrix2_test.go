@@ -1,8 +1,13 @@
 package somedata_test
 
 import (
+	"errors"
+	"math"
+	"math/rand"
 	"testing"
 
+	root "github.com/eterline/somedata"
+
 	somedata "github.com/eterline/somedata/matrix"
 )
 
@@ -143,3 +148,111 @@ func TestSize(t *testing.T) {
 		t.Errorf("expected size 4, got %v", s)
 	}
 }
+
+// newRowsMatrix2 - matrix with the given rows, row y is stored by Set(v, x, y)
+func newRowsMatrix2[T somedata.Numeric](rows ...[]T) somedata.Matrix[T] {
+	m := somedata.NewMatrix2[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x, v := range row {
+			m.Set(v, x, y)
+		}
+	}
+	return m
+}
+
+func TestMatMul(t *testing.T) {
+	a := newRowsMatrix2([]int{1, 2, 3}, []int{4, 5, 6})
+	b := newRowsMatrix2([]int{7, 8}, []int{9, 10}, []int{11, 12})
+
+	prod, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("MatMul() returned error: %v", err)
+	}
+
+	shape := prod.Shape()
+	if shape[0] != 2 || shape[1] != 2 {
+		t.Fatalf("expected shape [2,2], got %v", shape)
+	}
+
+	expected := [][]int{{58, 64}, {139, 154}}
+	for y, row := range expected {
+		for x, v := range row {
+			if got := prod.Get(x, y); got != v {
+				t.Errorf("MatMul mismatch at (%d, %d): got %v, want %v", x, y, got, v)
+			}
+		}
+	}
+}
+
+func TestMatMulShapeMismatch(t *testing.T) {
+	a := newRowsMatrix2([]int{1, 2, 3}, []int{4, 5, 6})
+
+	_, err := a.MatMul(a)
+	if !errors.Is(err, root.ErrMatMulShapes([]int{3, 2}, []int{3, 2})) {
+		t.Fatalf("expected shapes error, got %v", err)
+	}
+
+	if _, err := a.MulVec([]int{1, 2}); err == nil {
+		t.Fatalf("MulVec with short vector: expected error")
+	}
+	if _, err := somedata.NewMatrix3[int](2, 2, 2).MatMul(a); err == nil {
+		t.Fatalf("MatMul of 3D matrix: expected error")
+	}
+}
+
+func TestMulVec(t *testing.T) {
+	a := newRowsMatrix2([]int{1, 2, 3}, []int{4, 5, 6})
+
+	got, err := a.MulVec([]int{1, 0, -1})
+	if err != nil {
+		t.Fatalf("MulVec() returned error: %v", err)
+	}
+	if len(got) != 2 || got[0] != -2 || got[1] != -2 {
+		t.Fatalf("MulVec: got %v, want [-2 -2]", got)
+	}
+}
+
+func TestMatMulBlocked(t *testing.T) {
+	const n, k, p = 150, 70, 130
+	rnd := rand.New(rand.NewSource(1))
+
+	a := somedata.NewMatrix2[float64](k, n)
+	b := somedata.NewMatrix2[float64](p, k)
+	for _, m := range []somedata.Matrix[float64]{a, b} {
+		for i := range m.Flatten() {
+			m.Flatten()[i] = rnd.Float64()
+		}
+	}
+
+	prod, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("MatMul() returned error: %v", err)
+	}
+
+	for y := 0; y < n; y++ {
+		for x := 0; x < p; x++ {
+			var expected float64
+			for l := 0; l < k; l++ {
+				expected += a.Get(l, y) * b.Get(x, l)
+			}
+			if got := prod.Get(x, y); math.Abs(got-expected) > 1e-9 {
+				t.Fatalf("MatMul mismatch at (%d, %d): got %v, want %v", x, y, got, expected)
+			}
+		}
+	}
+}
+
+func BenchmarkMatMul1024(b *testing.B) {
+	const n = 1024
+	x := somedata.NewMatrix2[float64](n, n)
+	y := somedata.NewMatrix2[float64](n, n)
+	for i := range x.Flatten() {
+		x.Flatten()[i] = float64(i % 7)
+		y.Flatten()[i] = float64(i % 5)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.MatMul(y)
+	}
+}
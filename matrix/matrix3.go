@@ -101,6 +101,10 @@ func (mt *matrix3[T]) Scale(k T) Matrix[T] {
 }
 
 func (mt *matrix3[T]) Equals(m Matrix[T]) bool {
+	if _, ok := m.(*matrix3[T]); !ok {
+		return mt.view().Equals(m)
+	}
+
 	flt := m.Flatten()
 	return slices.Equal(mt.arr, flt)
 }
@@ -113,8 +117,8 @@ func (mt *matrix3[T]) Zero() {
 }
 
 func (mt *matrix3[T]) Add(m Matrix[T]) (Matrix[T], error) {
-	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+	if _, ok := m.(*matrix3[T]); !ok || !mt.ShapeEquals(m) {
+		return broadcast(mt, m, addOp[T])
 	}
 
 	newMt := mt.clone()
@@ -128,8 +132,8 @@ func (mt *matrix3[T]) Add(m Matrix[T]) (Matrix[T], error) {
 }
 
 func (mt *matrix3[T]) Sub(m Matrix[T]) (Matrix[T], error) {
-	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+	if _, ok := m.(*matrix3[T]); !ok || !mt.ShapeEquals(m) {
+		return broadcast(mt, m, subOp[T])
 	}
 
 	newMt := mt.clone()
@@ -143,8 +147,8 @@ func (mt *matrix3[T]) Sub(m Matrix[T]) (Matrix[T], error) {
 }
 
 func (mt *matrix3[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
-	if !mt.ShapeEquals(m) {
-		return nil, somedata.ErrMatUnequalShapes(mt.Rank())
+	if _, ok := m.(*matrix3[T]); !ok || !mt.ShapeEquals(m) {
+		return broadcast(mt, m, mulOp[T])
 	}
 
 	newMt := mt.clone()
@@ -156,3 +160,59 @@ func (mt *matrix3[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
 
 	return newMt, nil
 }
+
+// MatMul - product is not defined for 3D matrix
+func (mt *matrix3[T]) MatMul(m Matrix[T]) (Matrix[T], error) {
+	return nil, somedata.ErrMatMulRank(mt.Rank())
+}
+
+// MulVec - product is not defined for 3D matrix
+func (mt *matrix3[T]) MulVec(v []T) ([]T, error) {
+	return nil, somedata.ErrMatMulRank(mt.Rank())
+}
+
+// view - view sharing data store, x changes fastest
+func (mt *matrix3[T]) view() *matrixView[T] {
+	return &matrixView[T]{
+		shape:   mt.Shape(),
+		strides: []int{1, mt.width, mt.width * mt.height},
+		arr:     mt.arr,
+	}
+}
+
+func (mt *matrix3[T]) Transpose(axes ...int) View[T] {
+	return mt.view().Transpose(axes...)
+}
+
+// Reshape - view of elements taken in matrix3 order with x changing fastest,
+// so reshaping into any 3D shape gives matrix3 again
+func (mt *matrix3[T]) Reshape(shape ...int) (View[T], error) {
+	if err := checkReshape(shape, mt.Rank(), mt.Size()); err != nil {
+		return nil, err
+	}
+	if len(shape) == 3 {
+		return &matrix3[T]{width: shape[0], height: shape[1], deep: shape[2], arr: mt.arr}, nil
+	}
+
+	return &matrixView[T]{
+		shape:   slices.Clone(shape),
+		strides: colMajorStrides(shape),
+		arr:     mt.arr,
+	}, nil
+}
+
+func (mt *matrix3[T]) Slice(lo, hi []int) View[T] {
+	return mt.view().Slice(lo, hi)
+}
+
+func (mt *matrix3[T]) Row(i int) View[T] {
+	return mt.view().Row(i)
+}
+
+func (mt *matrix3[T]) Col(i int) View[T] {
+	return mt.view().Col(i)
+}
+
+func (mt *matrix3[T]) Materialize() Matrix[T] {
+	return mt.clone()
+}
@@ -0,0 +1,216 @@
+package somedata
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/eterline/somedata"
+)
+
+/*
+matrixN - matrix of any rank.
+Elements are stored in row-major order with the last axis
+changing fastest, the same layout as matrix2. matrix3 keeps
+x changing fastest, so it is compared and combined by its strides.
+*/
+type matrixN[T Numeric] struct {
+	shape []int
+	arr   []T // flat data store
+}
+
+// NewMatrixN - creates new matrix with shape sizes per axis
+func NewMatrixN[T Numeric](shape ...int) *matrixN[T] {
+	if len(shape) == 0 {
+		panic(somedata.ErrMatNegativeCoords(0))
+	}
+	for _, s := range shape {
+		if s < 1 {
+			panic(somedata.ErrMatNegativeCoords(len(shape)))
+		}
+	}
+
+	return &matrixN[T]{
+		shape: slices.Clone(shape),
+		arr:   make([]T, shapeSize(shape)),
+	}
+}
+
+func (mt *matrixN[T]) Rank() int {
+	return len(mt.shape)
+}
+
+func (mt *matrixN[T]) Shape() []int {
+	return slices.Clone(mt.shape)
+}
+
+func (mt *matrixN[T]) Size() int {
+	return len(mt.arr)
+}
+
+func (mt *matrixN[T]) ShapeEquals(m Matrix[T]) bool {
+	return shapeEq(mt, m)
+}
+
+func (mt *matrixN[T]) Get(coords ...int) T {
+	return mt.arr[coords2Idx(mt.shape, coords)]
+}
+
+func (mt *matrixN[T]) Set(value T, coords ...int) {
+	mt.arr[coords2Idx(mt.shape, coords)] = value
+}
+
+// All - iterator over coords and values in row-major order
+func (mt *matrixN[T]) All() iter.Seq2[[]int, T] {
+	return func(yield func([]int, T) bool) {
+		for i, value := range mt.arr {
+			if !yield(idx2Coords(mt.shape, i), value) {
+				return
+			}
+		}
+	}
+}
+
+func (mt *matrixN[T]) Flatten() []T {
+	return mt.arr
+}
+
+// clone - create new matrix with the same data values and sizes
+func (mt *matrixN[T]) clone() *matrixN[T] {
+	return &matrixN[T]{
+		shape: slices.Clone(mt.shape),
+		arr:   slices.Clone(mt.arr),
+	}
+}
+
+func (mt *matrixN[T]) Clone() Matrix[T] {
+	return mt.clone()
+}
+
+func (mt *matrixN[T]) Scale(k T) Matrix[T] {
+	cloned := mt.clone()
+	for i := range cloned.arr {
+		cloned.arr[i] *= k
+	}
+	return cloned
+}
+
+func (mt *matrixN[T]) Equals(m Matrix[T]) bool {
+	if !rowMajor(m) {
+		return slices.Equal(mt.arr, asView(m).flatCopy())
+	}
+
+	flt := m.Flatten()
+	return slices.Equal(mt.arr, flt)
+}
+
+func (mt *matrixN[T]) Zero() {
+	var dflt T
+	for i := range mt.arr {
+		mt.arr[i] = dflt
+	}
+}
+
+func (mt *matrixN[T]) Add(m Matrix[T]) (Matrix[T], error) {
+	if !mt.ShapeEquals(m) || !rowMajor(m) {
+		return broadcast(mt, m, addOp[T])
+	}
+
+	newMt := mt.clone()
+	addMt := m.Flatten()
+
+	for i := range newMt.arr {
+		newMt.arr[i] += addMt[i]
+	}
+
+	return newMt, nil
+}
+
+func (mt *matrixN[T]) Sub(m Matrix[T]) (Matrix[T], error) {
+	if !mt.ShapeEquals(m) || !rowMajor(m) {
+		return broadcast(mt, m, subOp[T])
+	}
+
+	newMt := mt.clone()
+	mFlat := m.Flatten()
+
+	for i := range newMt.arr {
+		newMt.arr[i] -= mFlat[i]
+	}
+
+	return newMt, nil
+}
+
+func (mt *matrixN[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
+	if !mt.ShapeEquals(m) || !rowMajor(m) {
+		return broadcast(mt, m, mulOp[T])
+	}
+
+	newMt := mt.clone()
+	mFlat := m.Flatten()
+
+	for i := range newMt.arr {
+		newMt.arr[i] *= mFlat[i]
+	}
+
+	return newMt, nil
+}
+
+// MatMul - matrix product, defined only for rank 2
+// with the same coords and layout as matrix2
+func (mt *matrixN[T]) MatMul(m Matrix[T]) (Matrix[T], error) {
+	if mt.Rank() != 2 {
+		return nil, somedata.ErrMatMulRank(mt.Rank())
+	}
+
+	shape := m.Shape()
+	if len(shape) != 2 || shape[1] != mt.shape[0] {
+		return nil, somedata.ErrMatMulShapes(mt.Shape(), shape)
+	}
+
+	newMt := NewMatrixN[T](shape[0], mt.shape[1])
+	matMul(m.Flatten(), mt.arr, newMt.arr, shape[0], mt.shape[0], mt.shape[1])
+	return newMt, nil
+}
+
+// MulVec - matrix-vector product, defined only for rank 2
+func (mt *matrixN[T]) MulVec(v []T) ([]T, error) {
+	if mt.Rank() != 2 {
+		return nil, somedata.ErrMatMulRank(mt.Rank())
+	}
+	if len(v) != mt.shape[0] {
+		return nil, somedata.ErrMatVecLength(mt.Rank(), mt.shape[0], len(v))
+	}
+
+	out := make([]T, mt.shape[1])
+	matVec(mt.arr, v, out, mt.shape[0])
+	return out, nil
+}
+
+// view - contiguous view sharing data store
+func (mt *matrixN[T]) view() *matrixView[T] {
+	return newView(mt.arr, mt.Shape())
+}
+
+func (mt *matrixN[T]) Transpose(axes ...int) View[T] {
+	return mt.view().Transpose(axes...)
+}
+
+func (mt *matrixN[T]) Reshape(shape ...int) (View[T], error) {
+	return mt.view().Reshape(shape...)
+}
+
+func (mt *matrixN[T]) Slice(lo, hi []int) View[T] {
+	return mt.view().Slice(lo, hi)
+}
+
+func (mt *matrixN[T]) Row(i int) View[T] {
+	return mt.view().Row(i)
+}
+
+func (mt *matrixN[T]) Col(i int) View[T] {
+	return mt.view().Col(i)
+}
+
+func (mt *matrixN[T]) Materialize() Matrix[T] {
+	return mt.clone()
+}
@@ -0,0 +1,134 @@
+package somedata_test
+
+import (
+	"slices"
+	"testing"
+
+	somedata "github.com/eterline/somedata/matrix"
+)
+
+func TestMatrixN_GetSet(t *testing.T) {
+	m := somedata.NewMatrixN[int](2, 3, 4, 5)
+
+	if m.Rank() != 4 || m.Size() != 120 {
+		t.Fatalf("expected rank 4 and size 120, got %d and %d", m.Rank(), m.Size())
+	}
+
+	m.Set(7, 1, 2, 3, 4)
+	if m.Get(1, 2, 3, 4) != 7 || m.Flatten()[119] != 7 {
+		t.Fatalf("Set/Get of the last element mismatch")
+	}
+
+	m.Set(3, 0, 1, 0, 2)
+	count := 0
+	for coords, v := range m.All() {
+		if v == 3 && !slices.Equal(coords, []int{0, 1, 0, 2}) {
+			t.Fatalf("All: value 3 at %v", coords)
+		}
+		count++
+	}
+	if count != 120 {
+		t.Fatalf("All: expected 120 elements, got %d", count)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on out of range coords")
+		}
+	}()
+	m.Get(2, 0, 0, 0)
+}
+
+func TestMatrixN_Arithmetic(t *testing.T) {
+	a := somedata.NewMatrixN[int](2, 2, 2)
+	b := somedata.NewMatrixN[int](2, 2, 2)
+	for i := range a.Flatten() {
+		a.Flatten()[i] = i
+		b.Flatten()[i] = 2
+	}
+
+	sum, err := a.Add(b)
+	if err != nil || !slices.Equal(sum.Flatten(), []int{2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("Add: got %v (%v)", sum, err)
+	}
+	prod, err := a.MulHadamard(b)
+	if err != nil || !prod.Equals(a.Scale(2)) {
+		t.Fatalf("MulHadamard is not equal to Scale(2): %v", err)
+	}
+	if _, err := a.Sub(somedata.NewMatrixN[int](2, 4)); err == nil {
+		t.Fatalf("Sub of unequal shapes: expected error")
+	}
+
+	// the same layout as 3D matrix
+	m3 := somedata.NewMatrix3[int](2, 2, 2)
+	copy(m3.Flatten(), a.Flatten())
+	if m3.Get(1, 0, 1) != a.Get(1, 0, 1) {
+		t.Fatalf("layout differs from 3D matrix")
+	}
+}
+
+func TestMatrixN_MatMul(t *testing.T) {
+	a := somedata.NewMatrixN[int](3, 2)
+	a2 := somedata.NewMatrix2[int](3, 2)
+	copy(a.Flatten(), []int{1, 4, 2, 5, 3, 6})
+	copy(a2.Flatten(), a.Flatten())
+
+	b := somedata.NewMatrix2[int](2, 3)
+	copy(b.Flatten(), []int{7, 9, 11, 8, 10, 12})
+
+	prod, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("MatMul() returned error: %v", err)
+	}
+	expected, _ := a2.MatMul(b)
+	if !prod.Equals(expected) || prod.Get(0, 1) != 139 {
+		t.Fatalf("MatMul: got %v, want %v", prod.Flatten(), expected.Flatten())
+	}
+
+	if v, err := a.MulVec([]int{1, 0, -1}); err != nil || !slices.Equal(v, []int{-2, -2}) {
+		t.Fatalf("MulVec: got %v (%v)", v, err)
+	}
+	if _, err := somedata.NewMatrixN[int](2, 2, 2).MatMul(b); err == nil {
+		t.Fatalf("MatMul of rank 3: expected error")
+	}
+}
+
+func TestMatrixN_Views(t *testing.T) {
+	m := somedata.NewMatrix2[int](2, 6).(somedata.View[int])
+	for i := range m.Flatten() {
+		m.Flatten()[i] = i
+	}
+
+	r, err := m.Reshape(1, 2, 2, 3)
+	if err != nil || r.Rank() != 4 || r.Get(0, 1, 1, 2) != 11 {
+		t.Fatalf("Reshape to rank 4: got %v", err)
+	}
+
+	tr := r.Transpose()
+	if !slices.Equal(tr.Shape(), []int{3, 2, 2, 1}) || tr.Get(2, 1, 1, 0) != 11 {
+		t.Fatalf("Transpose of rank 4: shape %v", tr.Shape())
+	}
+}
+
+func TestMatrixN_EqualsMatrix3(t *testing.T) {
+	m3 := somedata.NewMatrix3[int](2, 3, 4)
+	mn := somedata.NewMatrixN[int](2, 3, 4)
+	for i := range m3.Flatten() {
+		m3.Flatten()[i] = i
+	}
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 3; y++ {
+			for z := 0; z < 4; z++ {
+				mn.Set(m3.Get(x, y, z), x, y, z)
+			}
+		}
+	}
+
+	if !mn.Equals(m3) {
+		t.Fatalf("matrixN with the same elements as matrix3 is not equal to it")
+	}
+	mn.Set(-1, 1, 2, 3)
+	if mn.Equals(m3) {
+		t.Fatalf("matrixN with changed element is equal to matrix3")
+	}
+}
@@ -0,0 +1,381 @@
+package somedata
+
+import (
+	"slices"
+
+	"github.com/eterline/somedata"
+)
+
+// View - matrix which can be transposed, reshaped and sliced
+// without copying, all views share the same flat data store
+type View[T Numeric] interface {
+	Matrix[T]
+
+	// Transpose - view with permuted axes, reversed order when axes are empty
+	Transpose(axes ...int) View[T]
+	// Reshape - view of the same elements with another shape,
+	// fails for non-contiguous views
+	Reshape(shape ...int) (View[T], error)
+	// Slice - view over [lo, hi) ranges per axis
+	Slice(lo, hi []int) View[T]
+	// Row - view at index i of the last axis, one rank lower,
+	// for 2D matrix it is row y = i
+	Row(i int) View[T]
+	// Col - view at index i of the first axis, one rank lower,
+	// for 2D matrix it is column x = i
+	Col(i int) View[T]
+	// Materialize - contiguous copy of the view
+	Materialize() Matrix[T]
+}
+
+/*
+matrixView - strided window over flat data store of another matrix.
+Element at coords is arr[offset + sum(coords[i]*strides[i])],
+so transposition and slicing only change shape, strides and offset.
+*/
+type matrixView[T Numeric] struct {
+	shape   []int
+	strides []int
+	offset  int
+	arr     []T // shared flat data store
+}
+
+// rowMajorStrides - strides of contiguous store with the last axis changing fastest
+func rowMajorStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	return strides
+}
+
+// colMajorStrides - strides of contiguous store with the first axis changing fastest
+func colMajorStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for i := range shape {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+	return strides
+}
+
+func shapeSize(shape []int) int {
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+	return size
+}
+
+// newView - contiguous view over arr
+func newView[T Numeric](arr []T, shape []int) *matrixView[T] {
+	return &matrixView[T]{
+		shape:   shape,
+		strides: rowMajorStrides(shape),
+		arr:     arr,
+	}
+}
+
+// wrapFlat - matrix of shape over row-major arr without copying,
+// matrix3 keeps another order so 3D results are matrixN
+func wrapFlat[T Numeric](arr []T, shape []int) View[T] {
+	if len(shape) == 2 {
+		return &matrix2[T]{width: shape[0], height: shape[1], arr: arr}
+	}
+	return &matrixN[T]{shape: shape, arr: arr}
+}
+
+// viewer - matrices which can expose their store as strided view
+type viewer[T Numeric] interface {
+	view() *matrixView[T]
+}
+
+// asView - strided view of any matrix, matrices of other packages
+// are viewed over Flatten in row-major order
+func asView[T Numeric](m Matrix[T]) *matrixView[T] {
+	if v, ok := m.(viewer[T]); ok {
+		return v.view()
+	}
+	return newView(m.Flatten(), m.Shape())
+}
+
+// rowMajor - Flatten of m is in row-major order of its shape
+func rowMajor[T Numeric](m Matrix[T]) bool {
+	_, ok := m.(*matrix3[T])
+	return !ok
+}
+
+func (v *matrixView[T]) index(coords []int) int {
+	if len(coords) != len(v.shape) {
+		panic(somedata.ErrMatDimCoordMismatch(v.Rank()))
+	}
+
+	idx := v.offset
+	for i, c := range coords {
+		if c < 0 || c >= v.shape[i] {
+			panic(somedata.ErrMatOutCoords(v.Rank()))
+		}
+		idx += c * v.strides[i]
+	}
+	return idx
+}
+
+// contiguous - elements are placed in arr in row-major order without gaps
+func (v *matrixView[T]) contiguous() bool {
+	stride := 1
+	for i := len(v.shape) - 1; i >= 0; i-- {
+		if v.shape[i] != 1 && v.strides[i] != stride {
+			return false
+		}
+		stride *= v.shape[i]
+	}
+	return true
+}
+
+// each - calls fn with arr index of every element in row-major order
+func (v *matrixView[T]) each(fn func(idx int)) {
+	if v.Size() == 0 {
+		return
+	}
+
+	coords := make([]int, len(v.shape))
+	idx := v.offset
+	for {
+		fn(idx)
+
+		axis := len(coords) - 1
+		for ; axis >= 0; axis-- {
+			coords[axis]++
+			idx += v.strides[axis]
+			if coords[axis] < v.shape[axis] {
+				break
+			}
+			idx -= coords[axis] * v.strides[axis]
+			coords[axis] = 0
+		}
+		if axis < 0 {
+			return
+		}
+	}
+}
+
+// flatCopy - elements in row-major order in new slice
+func (v *matrixView[T]) flatCopy() []T {
+	out := make([]T, 0, v.Size())
+	v.each(func(idx int) {
+		out = append(out, v.arr[idx])
+	})
+	return out
+}
+
+func (v *matrixView[T]) Rank() int {
+	return len(v.shape)
+}
+
+func (v *matrixView[T]) Shape() []int {
+	return slices.Clone(v.shape)
+}
+
+func (v *matrixView[T]) Size() int {
+	return shapeSize(v.shape)
+}
+
+func (v *matrixView[T]) ShapeEquals(m Matrix[T]) bool {
+	return shapeEq(v, m)
+}
+
+func (v *matrixView[T]) Get(coords ...int) T {
+	return v.arr[v.index(coords)]
+}
+
+func (v *matrixView[T]) Set(value T, coords ...int) {
+	v.arr[v.index(coords)] = value
+}
+
+// Flatten - elements in row-major order with the last axis changing
+// fastest, the slice shares data store only for contiguous views
+// and is a copy otherwise
+func (v *matrixView[T]) Flatten() []T {
+	if v.contiguous() {
+		return v.arr[v.offset : v.offset+v.Size()]
+	}
+	return v.flatCopy()
+}
+
+func (v *matrixView[T]) Materialize() Matrix[T] {
+	return wrapFlat(v.flatCopy(), slices.Clone(v.shape))
+}
+
+func (v *matrixView[T]) Scale(k T) Matrix[T] {
+	flat := v.flatCopy()
+	for i := range flat {
+		flat[i] *= k
+	}
+	return wrapFlat(flat, slices.Clone(v.shape))
+}
+
+func (v *matrixView[T]) Equals(m Matrix[T]) bool {
+	if !rowMajor(m) {
+		return slices.Equal(v.flatCopy(), asView(m).flatCopy())
+	}
+	return slices.Equal(v.Flatten(), m.Flatten())
+}
+
+// Zero - sets viewed elements to default value, data store is shared
+func (v *matrixView[T]) Zero() {
+	var dflt T
+	v.each(func(idx int) {
+		v.arr[idx] = dflt
+	})
+}
+
+// elementwise - applies op to copy of viewed elements and elements of m
+func (v *matrixView[T]) elementwise(m Matrix[T], op func(a, b T) T) (Matrix[T], error) {
+	if !v.ShapeEquals(m) || !rowMajor(m) {
+		return broadcast(v, m, op)
+	}
+
+	flat := v.flatCopy()
+	mFlat := m.Flatten()
+	for i := range flat {
+		flat[i] = op(flat[i], mFlat[i])
+	}
+	return wrapFlat(flat, slices.Clone(v.shape)), nil
+}
+
+func (v *matrixView[T]) Add(m Matrix[T]) (Matrix[T], error) {
+	return v.elementwise(m, addOp[T])
+}
+
+func (v *matrixView[T]) Sub(m Matrix[T]) (Matrix[T], error) {
+	return v.elementwise(m, subOp[T])
+}
+
+func (v *matrixView[T]) MulHadamard(m Matrix[T]) (Matrix[T], error) {
+	return v.elementwise(m, mulOp[T])
+}
+
+func (v *matrixView[T]) MatMul(m Matrix[T]) (Matrix[T], error) {
+	if v.Rank() != 2 {
+		return nil, somedata.ErrMatMulRank(v.Rank())
+	}
+	return v.Materialize().MatMul(m)
+}
+
+func (v *matrixView[T]) MulVec(vec []T) ([]T, error) {
+	if v.Rank() != 2 {
+		return nil, somedata.ErrMatMulRank(v.Rank())
+	}
+	return v.Materialize().MulVec(vec)
+}
+
+func (v *matrixView[T]) Transpose(axes ...int) View[T] {
+	rank := v.Rank()
+	if len(axes) == 0 {
+		axes = make([]int, rank)
+		for i := range axes {
+			axes[i] = rank - 1 - i
+		}
+	}
+	if len(axes) != rank {
+		panic(somedata.ErrMatDimCoordMismatch(rank))
+	}
+
+	seen := make([]bool, rank)
+	out := &matrixView[T]{
+		shape:   make([]int, rank),
+		strides: make([]int, rank),
+		offset:  v.offset,
+		arr:     v.arr,
+	}
+	for i, axis := range axes {
+		if axis < 0 || axis >= rank || seen[axis] {
+			panic(somedata.ErrMatInvalidAxes(rank, axes))
+		}
+		seen[axis] = true
+		out.shape[i] = v.shape[axis]
+		out.strides[i] = v.strides[axis]
+	}
+	return out
+}
+
+// checkReshape - shape is valid and has size elements
+func checkReshape(shape []int, rank, size int) error {
+	if len(shape) == 0 {
+		return somedata.ErrMatReshapeSize(rank, size, shape)
+	}
+	for _, s := range shape {
+		if s < 1 {
+			return somedata.ErrMatNegativeCoords(len(shape))
+		}
+	}
+	if shapeSize(shape) != size {
+		return somedata.ErrMatReshapeSize(rank, size, shape)
+	}
+	return nil
+}
+
+func (v *matrixView[T]) Reshape(shape ...int) (View[T], error) {
+	if err := checkReshape(shape, v.Rank(), v.Size()); err != nil {
+		return nil, err
+	}
+	if !v.contiguous() {
+		return nil, somedata.ErrMatNotContiguous(v.Rank())
+	}
+	return wrapFlat(v.arr[v.offset:v.offset+v.Size()], slices.Clone(shape)), nil
+}
+
+func (v *matrixView[T]) Slice(lo, hi []int) View[T] {
+	rank := v.Rank()
+	if len(lo) != rank || len(hi) != rank {
+		panic(somedata.ErrMatDimCoordMismatch(rank))
+	}
+
+	out := &matrixView[T]{
+		shape:   make([]int, rank),
+		strides: slices.Clone(v.strides),
+		offset:  v.offset,
+		arr:     v.arr,
+	}
+	for i := range rank {
+		if lo[i] < 0 || hi[i] > v.shape[i] || lo[i] >= hi[i] {
+			panic(somedata.ErrMatOutCoords(rank))
+		}
+		out.shape[i] = hi[i] - lo[i]
+		out.offset += lo[i] * v.strides[i]
+	}
+	return out
+}
+
+// drop - view at index i of axis, one rank lower
+func (v *matrixView[T]) drop(axis, i int) View[T] {
+	rank := v.Rank()
+	if rank < 2 {
+		panic(somedata.ErrMatDimCoordMismatch(rank))
+	}
+	if i < 0 || i >= v.shape[axis] {
+		panic(somedata.ErrMatOutCoords(rank))
+	}
+
+	return &matrixView[T]{
+		shape:   slices.Delete(slices.Clone(v.shape), axis, axis+1),
+		strides: slices.Delete(slices.Clone(v.strides), axis, axis+1),
+		offset:  v.offset + i*v.strides[axis],
+		arr:     v.arr,
+	}
+}
+
+func (v *matrixView[T]) Row(i int) View[T] {
+	return v.drop(v.Rank()-1, i)
+}
+
+func (v *matrixView[T]) Col(i int) View[T] {
+	return v.drop(0, i)
+}
+
+func (v *matrixView[T]) view() *matrixView[T] {
+	return v
+}
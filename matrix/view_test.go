@@ -0,0 +1,145 @@
+package somedata_test
+
+import (
+	"slices"
+	"testing"
+
+	somedata "github.com/eterline/somedata/matrix"
+)
+
+func newRangeMatrix2(width, height int) somedata.View[int] {
+	m := somedata.NewMatrix2[int](width, height).(somedata.View[int])
+	for i := range m.Flatten() {
+		m.Flatten()[i] = i
+	}
+	return m
+}
+
+func TestViewTranspose(t *testing.T) {
+	m := newRangeMatrix2(2, 3)
+	tr := m.Transpose()
+
+	if shape := tr.Shape(); !slices.Equal(shape, []int{3, 2}) {
+		t.Fatalf("expected shape [3,2], got %v", shape)
+	}
+	if got := tr.Flatten(); !slices.Equal(got, []int{0, 3, 1, 4, 2, 5}) {
+		t.Fatalf("Flatten of transposed: got %v", got)
+	}
+
+	tr.Set(42, 2, 1)
+	if m.Get(1, 2) != 42 {
+		t.Fatalf("Set through view is not visible in source matrix")
+	}
+
+	if !tr.Transpose().Equals(m) {
+		t.Fatalf("double transposition is not equal to source")
+	}
+}
+
+func TestViewReshape(t *testing.T) {
+	m := newRangeMatrix2(4, 6)
+
+	r, err := m.Reshape(2, 3, 4)
+	if err != nil {
+		t.Fatalf("Reshape() returned error: %v", err)
+	}
+	if r.Rank() != 3 || r.Get(1, 2, 3) != 23 {
+		t.Fatalf("Reshape: expected rank 3 and element 23, got %d and %d", r.Rank(), r.Get(1, 2, 3))
+	}
+
+	r.Set(-1, 0, 0, 1)
+	if m.Get(0, 1) != -1 {
+		t.Fatalf("reshaped view does not share data store")
+	}
+
+	if _, err := m.Reshape(5, 5); err == nil {
+		t.Fatalf("Reshape to another size: expected error")
+	}
+	if _, err := m.Transpose().Reshape(24); err == nil {
+		t.Fatalf("Reshape of non-contiguous view: expected error")
+	}
+
+	flat, err := m.Transpose().Materialize().(somedata.View[int]).Reshape(24)
+	if err != nil || flat.Get(1) != 6 {
+		t.Fatalf("Reshape of materialized transposition: got %v", err)
+	}
+}
+
+func TestViewSliceRowCol(t *testing.T) {
+	m := newRangeMatrix2(4, 5)
+
+	sub := m.Slice([]int{1, 2}, []int{3, 5})
+	if got := sub.Flatten(); !slices.Equal(got, []int{7, 8, 9, 12, 13, 14}) {
+		t.Fatalf("Slice: got %v", got)
+	}
+
+	if got := m.Row(2).Flatten(); !slices.Equal(got, []int{2, 7, 12, 17}) {
+		t.Fatalf("Row(2): got %v", got)
+	}
+	if got := m.Col(1).Flatten(); !slices.Equal(got, []int{5, 6, 7, 8, 9}) {
+		t.Fatalf("Col(1): got %v", got)
+	}
+	if got := sub.Col(0).Flatten(); !slices.Equal(got, []int{7, 8, 9}) {
+		t.Fatalf("Col(0) of slice: got %v", got)
+	}
+
+	sub.Zero()
+	if m.Get(1, 2) != 0 || m.Get(2, 4) != 0 || m.Get(1, 1) != 6 {
+		t.Fatalf("Zero through slice changed wrong elements: %v", m.Flatten())
+	}
+
+	mat := m.Row(3).Materialize()
+	mat.Set(100, 0)
+	if m.Get(0, 3) == 100 {
+		t.Fatalf("Materialize shares data store")
+	}
+}
+
+func TestViewMatrix3(t *testing.T) {
+	m := somedata.NewMatrix3[int](2, 3, 4)
+	for i := range m.Flatten() {
+		m.Flatten()[i] = i
+	}
+	if m.Get(1, 0, 0) != 1 || m.Get(0, 1, 0) != 2 || m.Get(0, 0, 1) != 6 {
+		t.Fatalf("matrix3 layout changed: x must change fastest")
+	}
+
+	tr := m.Transpose(2, 0, 1)
+	if shape := tr.Shape(); !slices.Equal(shape, []int{4, 2, 3}) {
+		t.Fatalf("expected shape [4,2,3], got %v", shape)
+	}
+	if tr.Get(3, 1, 2) != m.Get(1, 2, 3) {
+		t.Fatalf("Transpose(2, 0, 1): element mismatch")
+	}
+
+	plane := m.Row(3)
+	if plane.Rank() != 2 || plane.Get(1, 2) != m.Get(1, 2, 3) {
+		t.Fatalf("Row(3) of 3D matrix: wrong plane")
+	}
+	if got := plane.Flatten(); !slices.Equal(got, []int{18, 20, 22, 19, 21, 23}) {
+		t.Fatalf("Flatten of plane: got %v", got)
+	}
+
+	sum, err := plane.Add(plane)
+	if err != nil || sum.Get(0, 0) != 2*m.Get(0, 0, 3) {
+		t.Fatalf("Add of views: got %v", err)
+	}
+
+	r, err := m.Reshape(6, 4)
+	if err != nil || r.Get(1, 0) != 1 || r.Get(0, 1) != 6 {
+		t.Fatalf("Reshape keeps matrix3 order: got %v", err)
+	}
+	if r3, _ := m.Reshape(4, 3, 2); !slices.Equal(r3.Flatten(), m.Flatten()) {
+		t.Fatalf("Reshape to 3D must share data store in the same order")
+	}
+	if !m.Transpose().Transpose().Equals(m) {
+		t.Fatalf("double transposition is not equal to source")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on repeated axes")
+		}
+	}()
+	m.Transpose(0, 0, 1)
+}
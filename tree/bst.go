@@ -1,18 +1,22 @@
 package somedata
 
 import (
+	"cmp"
+	"iter"
+
 	"github.com/eterline/somedata"
 	"golang.org/x/exp/constraints"
 )
 
-type NodeBST[T constraints.Ordered, D any] interface {
+type NodeBST[T any, D any] interface {
 	Value() T
 	Data() D
 }
 
-type nodeBST[T constraints.Ordered, D any] struct {
+type nodeBST[T any, D any] struct {
 	value T
 	data  D
+	count int // nodes count in subtree including itself
 	low   *nodeBST[T, D]
 	hight *nodeBST[T, D]
 }
@@ -31,19 +35,26 @@ func (n *nodeBST[T, D]) Data() D {
 	return n.data
 }
 
-func (n *nodeBST[T, D]) rm(value T) (*nodeBST[T, D], bool) {
+func (n *nodeBST[T, D]) rm(value T, compare func(a, b T) int) (*nodeBST[T, D], bool) {
 	if n == nil {
 		return nil, false
 	}
 
-	if value < n.value {
+	c := compare(value, n.value)
+	if c < 0 {
 		var deleted bool
-		n.low, deleted = n.low.rm(value)
+		n.low, deleted = n.low.rm(value, compare)
+		if deleted {
+			n.count--
+		}
 		return n, deleted
 	}
-	if value > n.value {
+	if c > 0 {
 		var deleted bool
-		n.hight, deleted = n.hight.rm(value)
+		n.hight, deleted = n.hight.rm(value, compare)
+		if deleted {
+			n.count--
+		}
 		return n, deleted
 	}
 
@@ -59,9 +70,63 @@ func (n *nodeBST[T, D]) rm(value T) (*nodeBST[T, D], bool) {
 
 	successor := n.hight.minNode()
 	n.value = successor.value
-	var deleted bool
-	n.hight, deleted = n.hight.rm(successor.value)
-	return n, deleted
+	n.data = successor.data
+	n.hight = n.hight.rmMin()
+	n.count--
+	return n, true
+}
+
+// rmMin - removes exactly the leftmost node of subtree
+func (n *nodeBST[T, D]) rmMin() *nodeBST[T, D] {
+	if n.low == nil {
+		return n.hight
+	}
+	n.low = n.low.rmMin()
+	n.count--
+	return n
+}
+
+func (n *nodeBST[T, D]) cnt() int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+// selectNode - k-th smallest node in subtree, k starts from 0
+func (n *nodeBST[T, D]) selectNode(k int) *nodeBST[T, D] {
+	cur := n
+	for cur != nil {
+		low := cur.low.cnt()
+		switch {
+		case k < low:
+			cur = cur.low
+		case k > low:
+			k -= low + 1
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+	return nil
+}
+
+// rank - count of values in subtree less than value
+func (n *nodeBST[T, D]) rank(value T, compare func(a, b T) int) int {
+	rank := 0
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c < 0:
+			cur = cur.low
+		case c > 0:
+			rank += cur.low.cnt() + 1
+			cur = cur.hight
+		default:
+			return rank + cur.low.cnt()
+		}
+	}
+	return rank
 }
 
 func (t *nodeBST[T, D]) min() T {
@@ -86,47 +151,196 @@ func (t *nodeBST[T, D]) max() T {
 	return t.hight.max()
 }
 
+func (n *nodeBST[T, D]) find(value T, compare func(a, b T) int) *nodeBST[T, D] {
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c < 0:
+			cur = cur.low
+		case c > 0:
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+	return nil
+}
+
+// floor - the greatest node with value <= value (or < value if strict)
+func (n *nodeBST[T, D]) floor(value T, strict bool, compare func(a, b T) int) *nodeBST[T, D] {
+	var found *nodeBST[T, D]
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c < 0, strict && c == 0:
+			cur = cur.low
+		case c > 0:
+			found = cur
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+	return found
+}
+
+// ceiling - the least node with value >= value (or > value if strict)
+func (n *nodeBST[T, D]) ceiling(value T, strict bool, compare func(a, b T) int) *nodeBST[T, D] {
+	var found *nodeBST[T, D]
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c > 0, strict && c == 0:
+			cur = cur.hight
+		case c < 0:
+			found = cur
+			cur = cur.low
+		default:
+			return cur
+		}
+	}
+	return found
+}
+
 func (t *nodeBST[T, D]) inOrder(fn func(T)) {
 	if t == nil {
 		return
 	}
 
-	fn(t.value)
 	t.low.inOrder(fn)
+	fn(t.value)
 	t.hight.inOrder(fn)
 }
 
-func insertNode[T constraints.Ordered, D any](node *nodeBST[T, D], value T, data D) *nodeBST[T, D] {
-	switch {
-	case node == nil:
-		return &nodeBST[T, D]{value: value, data: data}
+// ascend - in-order walk over [lo, hi] bounds, stops when yield returns false
+func (n *nodeBST[T, D]) ascend(lo, hi *T, compare func(a, b T) int, yield func(T, D) bool) bool {
+	if n == nil {
+		return true
+	}
 
-	case value < node.value:
-		node.low = insertNode(node.low, value, data)
+	if lo == nil || compare(*lo, n.value) < 0 {
+		if !n.low.ascend(lo, hi, compare, yield) {
+			return false
+		}
+	}
+	if (lo == nil || compare(*lo, n.value) <= 0) && (hi == nil || compare(n.value, *hi) <= 0) {
+		if !yield(n.value, n.data) {
+			return false
+		}
+	}
+	if hi == nil || compare(n.value, *hi) <= 0 {
+		return n.hight.ascend(lo, hi, compare, yield)
+	}
+	return true
+}
 
-	case value > node.value:
-		node.hight = insertNode(node.hight, value, data)
+// descend - reverse in-order walk, stops when yield returns false
+func (n *nodeBST[T, D]) descend(yield func(T, D) bool) bool {
+	if n == nil {
+		return true
 	}
+	return n.hight.descend(yield) && yield(n.value, n.data) && n.low.descend(yield)
+}
 
-	return node
+// insertNode - inserts value into subtree, equal values are resolved by policy.
+// Multiset duplicates are always placed into the hight subtree
+func insertNode[T any, D any](node *nodeBST[T, D], value T, data D, policy DuplicatePolicy, compare func(a, b T) int) (*nodeBST[T, D], bool) {
+	if node == nil {
+		return &nodeBST[T, D]{value: value, data: data, count: 1}, true
+	}
+
+	var added bool
+
+	switch c := compare(value, node.value); {
+	case c < 0:
+		node.low, added = insertNode(node.low, value, data, policy, compare)
+
+	case c > 0, policy == DuplicateMultiset:
+		node.hight, added = insertNode(node.hight, value, data, policy, compare)
+
+	case policy == DuplicateReplace:
+		node.data = data
+	}
+
+	if added {
+		node.count++
+	}
+	return node, added
 }
 
-type threeBST[T constraints.Ordered, D any] struct {
-	size int
-	root *nodeBST[T, D]
+// DuplicatePolicy - threeBST behavior on inserting already existing value
+type DuplicatePolicy int
+
+const (
+	DuplicateReject   DuplicatePolicy = iota // keep existing data, ignore new one
+	DuplicateReplace                         // replace existing data by new one
+	DuplicateMultiset                        // store every inserted value as a separate node
+)
+
+type threeBST[T any, D any] struct {
+	size   int
+	policy DuplicatePolicy
+	cmp    func(a, b T) int
+	root   *nodeBST[T, D]
 }
 
 func NewThreeBST[T constraints.Ordered, D any]() *threeBST[T, D] {
-	return &threeBST[T, D]{}
+	return &threeBST[T, D]{cmp: cmp.Compare[T]}
+}
+
+// NewThreeBSTFunc - creates BST ordered by compare function,
+// compare must return a negative number when a < b, zero when a == b
+// and a positive number when a > b (the same contract as cmp.Compare)
+func NewThreeBSTFunc[T any, D any](compare func(a, b T) int) *threeBST[T, D] {
+	return &threeBST[T, D]{cmp: compare}
 }
 
 func (t *threeBST[T, D]) Size() int {
 	return t.size
 }
 
-func (t *threeBST[T, D]) Insert(value T, data D) {
-	t.root = insertNode(t.root, value, data)
+// SetDuplicatePolicy - changes behavior of Insert for already existing values
+func (t *threeBST[T, D]) SetDuplicatePolicy(policy DuplicatePolicy) {
+	t.policy = policy
+}
+
+// Insert - adds value with data according to duplicate policy,
+// returns true when a new node was added
+func (t *threeBST[T, D]) Insert(value T, data D) bool {
+	var added bool
+	t.root, added = insertNode(t.root, value, data, t.policy, t.cmp)
+	if added {
+		t.size++
+	}
+	return added
+}
+
+// Upsert - replaces data of existing value or inserts a new one.
+// Returns previous data and true if value already existed
+func (t *threeBST[T, D]) Upsert(value T, data D) (D, bool) {
+	if prev, ok := t.Replace(value, data); ok {
+		return prev, true
+	}
+
+	t.root, _ = insertNode(t.root, value, data, DuplicateReject, t.cmp)
 	t.size++
+
+	var zero D
+	return zero, false
+}
+
+// Replace - replaces data of existing value only.
+// Returns previous data and true if value exists
+func (t *threeBST[T, D]) Replace(value T, data D) (D, bool) {
+	node := t.root.find(value, t.cmp)
+	if node == nil {
+		var zero D
+		return zero, false
+	}
+
+	prev := node.data
+	node.data = data
+	return prev, true
 }
 
 func (t *threeBST[T, D]) Min() (T, bool) {
@@ -145,6 +359,7 @@ func (t *threeBST[T, D]) Max() (T, bool) {
 	return t.root.max(), true
 }
 
+// InOrder - calls fn for every value in ascending order
 func (t *threeBST[T, D]) InOrder(fn func(T)) {
 	if t.size == 0 {
 		return
@@ -157,10 +372,206 @@ func (t *threeBST[T, D]) Delete(value T) (ok bool) {
 		return false
 	}
 
-	t.root, ok = t.root.rm(value)
+	t.root, ok = t.root.rm(value, t.cmp)
 	if ok {
 		t.size--
 	}
 
 	return ok
 }
+
+// Get - returns data stored under value
+func (t *threeBST[T, D]) Get(value T) (D, bool) {
+	node := t.root.find(value, t.cmp)
+	if node == nil {
+		var zero D
+		return zero, false
+	}
+	return node.data, true
+}
+
+// Contains - value existing in tree
+func (t *threeBST[T, D]) Contains(value T) bool {
+	return t.root.find(value, t.cmp) != nil
+}
+
+// Floor - node with the greatest value less than or equal to value
+func (t *threeBST[T, D]) Floor(value T) (NodeBST[T, D], bool) {
+	return wrapNodeBST(t.root.floor(value, false, t.cmp))
+}
+
+// Ceiling - node with the least value greater than or equal to value
+func (t *threeBST[T, D]) Ceiling(value T) (NodeBST[T, D], bool) {
+	return wrapNodeBST(t.root.ceiling(value, false, t.cmp))
+}
+
+// Predecessor - node with the greatest value strictly less than value
+func (t *threeBST[T, D]) Predecessor(value T) (NodeBST[T, D], bool) {
+	return wrapNodeBST(t.root.floor(value, true, t.cmp))
+}
+
+// Successor - node with the least value strictly greater than value
+func (t *threeBST[T, D]) Successor(value T) (NodeBST[T, D], bool) {
+	return wrapNodeBST(t.root.ceiling(value, true, t.cmp))
+}
+
+// wrapNodeBST - prevents typed nil pointer inside of NodeBST interface
+func wrapNodeBST[T any, D any](n *nodeBST[T, D]) (NodeBST[T, D], bool) {
+	if n == nil {
+		return nil, false
+	}
+	return n, true
+}
+
+// All - iterator over values and data in ascending order
+func (t *threeBST[T, D]) All() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		t.root.ascend(nil, nil, t.cmp, yield)
+	}
+}
+
+// Backward - iterator over values and data in descending order
+func (t *threeBST[T, D]) Backward() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		t.root.descend(yield)
+	}
+}
+
+// Range - iterator over values within [lo, hi] in ascending order
+func (t *threeBST[T, D]) Range(lo, hi T) iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		if t.cmp(hi, lo) < 0 {
+			return
+		}
+		t.root.ascend(&lo, &hi, t.cmp, yield)
+	}
+}
+
+// Select - k-th smallest value with its data, k starts from 0
+func (t *threeBST[T, D]) Select(k int) (T, D, bool) {
+	node := t.root.selectNode(k)
+	if k < 0 || node == nil {
+		var (
+			zeroT T
+			zeroD D
+		)
+		return zeroT, zeroD, false
+	}
+	return node.value, node.data, true
+}
+
+// Rank - number of values in tree less than value
+func (t *threeBST[T, D]) Rank(value T) int {
+	return t.root.rank(value, t.cmp)
+}
+
+// buildSorted - builds perfectly balanced subtree from sorted values
+func buildSorted[T any, D any](values []T, data []D) *nodeBST[T, D] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	mid := len(values) / 2
+	node := &nodeBST[T, D]{value: values[mid], count: len(values)}
+	if data != nil {
+		node.data = data[mid]
+	}
+
+	node.low = buildSorted(values[:mid], sliceOrNil(data, 0, mid))
+	node.hight = buildSorted(values[mid+1:], sliceOrNil(data, mid+1, len(values)))
+	return node
+}
+
+func sliceOrNil[D any](data []D, from, to int) []D {
+	if data == nil {
+		return nil
+	}
+	return data[from:to]
+}
+
+// split - splits subtree into values less than value and all others
+func (n *nodeBST[T, D]) split(value T, compare func(a, b T) int) (*nodeBST[T, D], *nodeBST[T, D]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if compare(n.value, value) < 0 {
+		low, hight := n.hight.split(value, compare)
+		n.hight = low
+		n.count = n.low.cnt() + n.hight.cnt() + 1
+		return n, hight
+	}
+
+	low, hight := n.low.split(value, compare)
+	n.low = hight
+	n.count = n.low.cnt() + n.hight.cnt() + 1
+	return low, n
+}
+
+// BuildFromSorted - creates balanced BST from strictly ascending values in O(n).
+// data may be nil, otherwise it must have the same length as values
+func BuildFromSorted[T constraints.Ordered, D any](values []T, data []D) (*threeBST[T, D], error) {
+	return BuildFromSortedFunc(values, data, cmp.Compare[T])
+}
+
+// BuildFromSortedFunc - BuildFromSorted for values ordered by compare function
+func BuildFromSortedFunc[T any, D any](values []T, data []D, compare func(a, b T) int) (*threeBST[T, D], error) {
+	if data != nil && len(data) != len(values) {
+		return nil, somedata.ErrBstLengthMismatch
+	}
+
+	for i := 1; i < len(values); i++ {
+		if compare(values[i-1], values[i]) >= 0 {
+			return nil, somedata.ErrBstNotSorted
+		}
+	}
+
+	return &threeBST[T, D]{
+		size: len(values),
+		cmp:  compare,
+		root: buildSorted(values, data),
+	}, nil
+}
+
+// Split - moves values less than value into the first tree and all others
+// into the second one. Receiver becomes empty, its nodes are reused
+func (t *threeBST[T, D]) Split(value T) (*threeBST[T, D], *threeBST[T, D]) {
+	low, hight := t.root.split(value, t.cmp)
+
+	left := &threeBST[T, D]{size: low.cnt(), policy: t.policy, cmp: t.cmp, root: low}
+	right := &threeBST[T, D]{size: hight.cnt(), policy: t.policy, cmp: t.cmp, root: hight}
+
+	t.root = nil
+	t.size = 0
+	return left, right
+}
+
+// Join - concatenates two trees where every value of left is less than
+// every value of right. Both arguments become empty, their nodes are reused
+func Join[T any, D any](left, right *threeBST[T, D]) (*threeBST[T, D], error) {
+	joined := &threeBST[T, D]{policy: left.policy, cmp: left.cmp}
+
+	switch {
+	case left.root == nil:
+		joined.root = right.root
+	case right.root == nil:
+		joined.root = left.root
+	default:
+		leftMax := left.root.max()
+		rightMin := right.root.minNode()
+		if left.cmp(leftMax, rightMin.value) >= 0 {
+			return nil, somedata.ErrBstJoinOverlap
+		}
+
+		right.root = right.root.rmMin()
+		rightMin.low = left.root
+		rightMin.hight = right.root
+		rightMin.count = left.size + right.size
+		joined.root = rightMin
+	}
+
+	joined.size = left.size + right.size
+	left.root, left.size = nil, 0
+	right.root, right.size = nil, 0
+	return joined, nil
+}
@@ -1,8 +1,10 @@
 package somedata_test
 
 import (
+	"cmp"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	somedata "github.com/eterline/somedata/tree"
@@ -23,7 +25,7 @@ func TestThreeBST_InsertAndInOrder(t *testing.T) {
 	got := []int{}
 	tree.InOrder(func(v int) { got = append(got, v) })
 
-	expected := []int{50, 30, 20, 40, 70, 60, 80}
+	expected := []int{20, 30, 40, 50, 60, 70, 80}
 	if !reflect.DeepEqual(got, expected) {
 		t.Fatalf("inOrder: expected %v, got %v", expected, got)
 	}
@@ -91,3 +93,385 @@ func TestThreeBST_DeleteNonExistent(t *testing.T) {
 		t.Fatalf("expected size 2, got %d", tree.Size())
 	}
 }
+
+func TestThreeBST_GetContains(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	values := []int{50, 30, 70, 20, 40, 60, 80}
+	for _, v := range values {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+
+	for _, v := range values {
+		data, ok := tree.Get(v)
+		if !ok || data != strconv.Itoa(v) {
+			t.Fatalf("Get(%d): expected %q, got %q", v, strconv.Itoa(v), data)
+		}
+		if !tree.Contains(v) {
+			t.Fatalf("Contains(%d): expected true", v)
+		}
+	}
+
+	if _, ok := tree.Get(55); ok {
+		t.Fatalf("Get(55): expected missing key")
+	}
+	if tree.Contains(55) {
+		t.Fatalf("Contains(55): expected false")
+	}
+}
+
+func TestThreeBST_FloorCeiling(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	values := []int{50, 30, 70, 20, 40, 60, 80}
+	for _, v := range values {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+
+	type query struct {
+		name   string
+		fn     func(int) (somedata.NodeBST[int, string], bool)
+		arg    int
+		want   int
+		wantOk bool
+	}
+
+	queries := []query{
+		{"Floor", tree.Floor, 55, 50, true},
+		{"Floor", tree.Floor, 50, 50, true},
+		{"Floor", tree.Floor, 10, 0, false},
+		{"Ceiling", tree.Ceiling, 55, 60, true},
+		{"Ceiling", tree.Ceiling, 60, 60, true},
+		{"Ceiling", tree.Ceiling, 90, 0, false},
+		{"Predecessor", tree.Predecessor, 50, 40, true},
+		{"Predecessor", tree.Predecessor, 20, 0, false},
+		{"Successor", tree.Successor, 40, 50, true},
+		{"Successor", tree.Successor, 80, 0, false},
+	}
+
+	for _, q := range queries {
+		node, ok := q.fn(q.arg)
+		if ok != q.wantOk {
+			t.Fatalf("%s(%d): expected ok=%v, got %v", q.name, q.arg, q.wantOk, ok)
+		}
+		if !ok {
+			if node != nil {
+				t.Fatalf("%s(%d): expected nil node", q.name, q.arg)
+			}
+			continue
+		}
+		if node.Value() != q.want || node.Data() != strconv.Itoa(q.want) {
+			t.Fatalf("%s(%d): expected %d, got %d", q.name, q.arg, q.want, node.Value())
+		}
+	}
+}
+
+func TestThreeBST_Iterators(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	values := []int{50, 30, 70, 20, 40, 60, 80}
+	for _, v := range values {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+
+	got := []int{}
+	for k, d := range tree.All() {
+		if d != strconv.Itoa(k) {
+			t.Fatalf("All: data mismatch for %d: %q", k, d)
+		}
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{20, 30, 40, 50, 60, 70, 80}) {
+		t.Fatalf("All: got %v", got)
+	}
+
+	got = got[:0]
+	for k := range tree.Backward() {
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{80, 70, 60, 50, 40, 30, 20}) {
+		t.Fatalf("Backward: got %v", got)
+	}
+
+	got = got[:0]
+	for k := range tree.Range(35, 70) {
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{40, 50, 60, 70}) {
+		t.Fatalf("Range(35, 70): got %v", got)
+	}
+
+	got = got[:0]
+	for k := range tree.All() {
+		if k > 40 {
+			break
+		}
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{20, 30, 40}) {
+		t.Fatalf("All with break: got %v", got)
+	}
+
+	for range tree.Range(70, 35) {
+		t.Fatalf("Range(70, 35): expected empty sequence")
+	}
+}
+
+func TestThreeBST_SelectRank(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	values := []int{50, 30, 70, 20, 40, 60, 80}
+	for _, v := range values {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+	tree.Insert(50, "dup")
+	tree.Delete(30)
+
+	sorted := []int{20, 40, 50, 60, 70, 80}
+	if tree.Size() != len(sorted) {
+		t.Fatalf("expected size %d, got %d", len(sorted), tree.Size())
+	}
+
+	for i, v := range sorted {
+		key, _, ok := tree.Select(i)
+		if !ok || key != v {
+			t.Fatalf("Select(%d): expected %d, got %d", i, v, key)
+		}
+		if rank := tree.Rank(v); rank != i {
+			t.Fatalf("Rank(%d): expected %d, got %d", v, i, rank)
+		}
+	}
+
+	if _, _, ok := tree.Select(len(sorted)); ok {
+		t.Fatalf("Select(%d): expected out of range", len(sorted))
+	}
+	if _, _, ok := tree.Select(-1); ok {
+		t.Fatalf("Select(-1): expected out of range")
+	}
+	if rank := tree.Rank(55); rank != 3 {
+		t.Fatalf("Rank(55): expected 3, got %d", rank)
+	}
+	if rank := tree.Rank(100); rank != len(sorted) {
+		t.Fatalf("Rank(100): expected %d, got %d", len(sorted), rank)
+	}
+}
+
+func TestThreeBST_DeleteKeepsData(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	values := []int{50, 30, 70, 20, 40, 60, 80}
+	for _, v := range values {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+
+	tree.Delete(50)
+	tree.Delete(30)
+
+	for k, d := range tree.All() {
+		if d != strconv.Itoa(k) {
+			t.Fatalf("data %q attached to wrong key %d", d, k)
+		}
+	}
+}
+
+func TestThreeBST_UpsertReplace(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+
+	if _, ok := tree.Replace(1, "one"); ok {
+		t.Fatalf("Replace on missing key: expected false")
+	}
+	if tree.Size() != 0 {
+		t.Fatalf("Replace must not insert, got size %d", tree.Size())
+	}
+
+	if _, ok := tree.Upsert(1, "one"); ok {
+		t.Fatalf("Upsert of new key: expected false")
+	}
+	prev, ok := tree.Upsert(1, "uno")
+	if !ok || prev != "one" {
+		t.Fatalf("Upsert of existing key: expected (one, true), got (%s, %v)", prev, ok)
+	}
+	prev, ok = tree.Replace(1, "eins")
+	if !ok || prev != "uno" {
+		t.Fatalf("Replace: expected (uno, true), got (%s, %v)", prev, ok)
+	}
+
+	if tree.Size() != 1 {
+		t.Fatalf("expected size 1, got %d", tree.Size())
+	}
+	if data, _ := tree.Get(1); data != "eins" {
+		t.Fatalf("expected data eins, got %s", data)
+	}
+}
+
+func TestThreeBST_DuplicatePolicy(t *testing.T) {
+	reject := somedata.NewThreeBST[int, string]()
+	reject.Insert(1, "a")
+	if reject.Insert(1, "b") {
+		t.Fatalf("reject: expected Insert of duplicate = false")
+	}
+	if data, _ := reject.Get(1); data != "a" || reject.Size() != 1 {
+		t.Fatalf("reject: expected (a, 1), got (%s, %d)", data, reject.Size())
+	}
+
+	replace := somedata.NewThreeBST[int, string]()
+	replace.SetDuplicatePolicy(somedata.DuplicateReplace)
+	replace.Insert(1, "a")
+	replace.Insert(1, "b")
+	if data, _ := replace.Get(1); data != "b" || replace.Size() != 1 {
+		t.Fatalf("replace: expected (b, 1), got (%s, %d)", data, replace.Size())
+	}
+
+	multi := somedata.NewThreeBST[int, string]()
+	multi.SetDuplicatePolicy(somedata.DuplicateMultiset)
+	for _, v := range []int{5, 3, 5, 8, 5, 3} {
+		if !multi.Insert(v, strconv.Itoa(v)) {
+			t.Fatalf("multiset: expected Insert(%d) = true", v)
+		}
+	}
+	if multi.Size() != 6 {
+		t.Fatalf("multiset: expected size 6, got %d", multi.Size())
+	}
+
+	got := []int{}
+	for k := range multi.Range(5, 5) {
+		got = append(got, k)
+	}
+	if !reflect.DeepEqual(got, []int{5, 5, 5}) {
+		t.Fatalf("multiset: Range(5, 5) got %v", got)
+	}
+	if rank := multi.Rank(8); rank != 5 {
+		t.Fatalf("multiset: Rank(8) expected 5, got %d", rank)
+	}
+
+	multi.Delete(5)
+	multi.Delete(5)
+	got = got[:0]
+	multi.InOrder(func(v int) { got = append(got, v) })
+	if !reflect.DeepEqual(got, []int{3, 3, 5, 8}) || multi.Size() != 4 {
+		t.Fatalf("multiset: after deletes got %v (size %d)", got, multi.Size())
+	}
+}
+
+type tenantKey struct {
+	tenant string
+	ts     int
+}
+
+func compareTenantKey(a, b tenantKey) int {
+	if c := strings.Compare(a.tenant, b.tenant); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ts, b.ts)
+}
+
+func TestThreeBST_Comparator(t *testing.T) {
+	tree := somedata.NewThreeBSTFunc[tenantKey, int](compareTenantKey)
+
+	keys := []tenantKey{{"b", 2}, {"a", 3}, {"b", 1}, {"a", 1}, {"c", 0}}
+	for i, k := range keys {
+		tree.Insert(k, i)
+	}
+
+	got := []tenantKey{}
+	for k := range tree.Range(tenantKey{"a", 2}, tenantKey{"b", 1}) {
+		got = append(got, k)
+	}
+	expected := []tenantKey{{"a", 3}, {"b", 1}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Range: expected %v, got %v", expected, got)
+	}
+
+	if data, ok := tree.Get(tenantKey{"b", 2}); !ok || data != 0 {
+		t.Fatalf("Get: expected 0, got %d (%v)", data, ok)
+	}
+
+	reverse := somedata.NewThreeBSTFunc[int, any](func(a, b int) int { return cmp.Compare(b, a) })
+	for _, v := range []int{3, 1, 2} {
+		reverse.Insert(v, nil)
+	}
+	min, _ := reverse.Min()
+	if min != 3 {
+		t.Fatalf("reverse Min: expected 3, got %d", min)
+	}
+}
+
+func TestThreeBST_BuildFromSorted(t *testing.T) {
+	values := make([]int, 100)
+	data := make([]string, 100)
+	for i := range values {
+		values[i] = i * 2
+		data[i] = strconv.Itoa(i * 2)
+	}
+
+	tree, err := somedata.BuildFromSorted(values, data)
+	if err != nil {
+		t.Fatalf("BuildFromSorted: unexpected error %v", err)
+	}
+	if tree.Size() != len(values) {
+		t.Fatalf("expected size %d, got %d", len(values), tree.Size())
+	}
+	for i, v := range values {
+		if got, _, _ := tree.Select(i); got != v {
+			t.Fatalf("Select(%d): expected %d, got %d", i, v, got)
+		}
+		if d, _ := tree.Get(v); d != data[i] {
+			t.Fatalf("Get(%d): expected %s, got %s", v, data[i], d)
+		}
+	}
+
+	if _, err := somedata.BuildFromSorted([]int{1, 3, 2}, []string(nil)); err == nil {
+		t.Fatalf("expected error on unsorted values")
+	}
+	if _, err := somedata.BuildFromSorted([]int{1, 2}, []string{"1"}); err == nil {
+		t.Fatalf("expected error on length mismatch")
+	}
+}
+
+func TestThreeBST_SplitJoin(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		tree.Insert(v, strconv.Itoa(v))
+	}
+
+	left, right := tree.Split(55)
+	if tree.Size() != 0 {
+		t.Fatalf("Split must empty receiver, got size %d", tree.Size())
+	}
+
+	collect := func(tr interface{ InOrder(func(int)) }) []int {
+		got := []int{}
+		tr.InOrder(func(v int) { got = append(got, v) })
+		return got
+	}
+
+	if got := collect(left); !reflect.DeepEqual(got, []int{20, 30, 40, 50}) || left.Size() != 4 {
+		t.Fatalf("Split left: got %v (size %d)", got, left.Size())
+	}
+	if got := collect(right); !reflect.DeepEqual(got, []int{60, 70, 80}) || right.Size() != 3 {
+		t.Fatalf("Split right: got %v (size %d)", got, right.Size())
+	}
+	if rank := right.Rank(80); rank != 2 {
+		t.Fatalf("Split right: Rank(80) expected 2, got %d", rank)
+	}
+
+	if _, err := somedata.Join(right, left); err == nil {
+		t.Fatalf("Join: expected error on overlapping ranges")
+	}
+
+	joined, err := somedata.Join(left, right)
+	if err != nil {
+		t.Fatalf("Join: unexpected error %v", err)
+	}
+	if got := collect(joined); !reflect.DeepEqual(got, []int{20, 30, 40, 50, 60, 70, 80}) || joined.Size() != 7 {
+		t.Fatalf("Join: got %v (size %d)", got, joined.Size())
+	}
+	for k, d := range joined.All() {
+		if d != strconv.Itoa(k) {
+			t.Fatalf("Join: data %q attached to wrong key %d", d, k)
+		}
+	}
+	if key, _, _ := joined.Select(4); key != 60 {
+		t.Fatalf("Join: Select(4) expected 60, got %d", key)
+	}
+}
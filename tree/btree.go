@@ -0,0 +1,406 @@
+package somedata
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/eterline/somedata"
+	"golang.org/x/exp/constraints"
+)
+
+type nodeB[T any, D any] struct {
+	keys  []T
+	data  []D
+	child []*nodeB[T, D] // empty for leaf nodes
+}
+
+func (n *nodeB[T, D]) leaf() bool {
+	return len(n.child) == 0
+}
+
+func (n *nodeB[T, D]) search(key T, compare func(a, b T) int) (int, bool) {
+	return slices.BinarySearchFunc(n.keys, key, compare)
+}
+
+func (n *nodeB[T, D]) minNode() *nodeB[T, D] {
+	cur := n
+	for !cur.leaf() {
+		cur = cur.child[0]
+	}
+	return cur
+}
+
+func (n *nodeB[T, D]) maxNode() *nodeB[T, D] {
+	cur := n
+	for !cur.leaf() {
+		cur = cur.child[len(cur.child)-1]
+	}
+	return cur
+}
+
+func (n *nodeB[T, D]) find(key T, compare func(a, b T) int) (*nodeB[T, D], int) {
+	cur := n
+	for cur != nil {
+		i, found := cur.search(key, compare)
+		if found {
+			return cur, i
+		}
+		if cur.leaf() {
+			return nil, 0
+		}
+
+		cur = cur.child[i]
+	}
+	return nil, 0
+}
+
+// splitChild - splits full child i into two nodes and lifts its median key
+func (n *nodeB[T, D]) splitChild(i, degree int) {
+	full := n.child[i]
+	mid := degree - 1
+
+	right := &nodeB[T, D]{
+		keys: make([]T, 0, 2*degree-1),
+		data: make([]D, 0, 2*degree-1),
+	}
+	right.keys = append(right.keys, full.keys[degree:]...)
+	right.data = append(right.data, full.data[degree:]...)
+
+	if !full.leaf() {
+		right.child = make([]*nodeB[T, D], 0, 2*degree)
+		right.child = append(right.child, full.child[degree:]...)
+		clear(full.child[degree:])
+		full.child = full.child[:degree]
+	}
+
+	n.keys = slices.Insert(n.keys, i, full.keys[mid])
+	n.data = slices.Insert(n.data, i, full.data[mid])
+	n.child = slices.Insert(n.child, i+1, right)
+
+	clear(full.keys[mid:])
+	clear(full.data[mid:])
+	full.keys = full.keys[:mid]
+	full.data = full.data[:mid]
+}
+
+// insertNonFull - inserts key into subtree which root has free key slot
+func (n *nodeB[T, D]) insertNonFull(key T, data D, degree int, compare func(a, b T) int) (D, bool) {
+	cur := n
+	for {
+		i, found := cur.search(key, compare)
+		if found {
+			prev := cur.data[i]
+			cur.data[i] = data
+			return prev, true
+		}
+
+		if cur.leaf() {
+			cur.keys = slices.Insert(cur.keys, i, key)
+			cur.data = slices.Insert(cur.data, i, data)
+			var zero D
+			return zero, false
+		}
+
+		if len(cur.child[i].keys) == 2*degree-1 {
+			cur.splitChild(i, degree)
+			switch c := compare(key, cur.keys[i]); {
+			case c == 0:
+				prev := cur.data[i]
+				cur.data[i] = data
+				return prev, true
+			case c > 0:
+				i++
+			}
+		}
+		cur = cur.child[i]
+	}
+}
+
+// merge - joins child i, separator key i and child i+1 into child i
+func (n *nodeB[T, D]) merge(i int) {
+	low, hight := n.child[i], n.child[i+1]
+
+	low.keys = append(low.keys, n.keys[i])
+	low.keys = append(low.keys, hight.keys...)
+	low.data = append(low.data, n.data[i])
+	low.data = append(low.data, hight.data...)
+	low.child = append(low.child, hight.child...)
+
+	n.keys = slices.Delete(n.keys, i, i+1)
+	n.data = slices.Delete(n.data, i, i+1)
+	n.child = slices.Delete(n.child, i+1, i+2)
+}
+
+func (n *nodeB[T, D]) borrowFromPrev(i int) {
+	cur, sib := n.child[i], n.child[i-1]
+	last := len(sib.keys) - 1
+
+	cur.keys = slices.Insert(cur.keys, 0, n.keys[i-1])
+	cur.data = slices.Insert(cur.data, 0, n.data[i-1])
+	n.keys[i-1] = sib.keys[last]
+	n.data[i-1] = sib.data[last]
+
+	var zero D
+	sib.data[last] = zero
+	sib.keys = sib.keys[:last]
+	sib.data = sib.data[:last]
+
+	if !sib.leaf() {
+		lastChild := len(sib.child) - 1
+		cur.child = slices.Insert(cur.child, 0, sib.child[lastChild])
+		sib.child[lastChild] = nil
+		sib.child = sib.child[:lastChild]
+	}
+}
+
+func (n *nodeB[T, D]) borrowFromNext(i int) {
+	cur, sib := n.child[i], n.child[i+1]
+
+	cur.keys = append(cur.keys, n.keys[i])
+	cur.data = append(cur.data, n.data[i])
+	n.keys[i] = sib.keys[0]
+	n.data[i] = sib.data[0]
+
+	sib.keys = slices.Delete(sib.keys, 0, 1)
+	sib.data = slices.Delete(sib.data, 0, 1)
+
+	if !sib.leaf() {
+		cur.child = append(cur.child, sib.child[0])
+		sib.child = slices.Delete(sib.child, 0, 1)
+	}
+}
+
+// fill - guarantees that child i has at least degree keys, returns new index of that child
+func (n *nodeB[T, D]) fill(i, degree int) int {
+	switch {
+	case i > 0 && len(n.child[i-1].keys) >= degree:
+		n.borrowFromPrev(i)
+	case i < len(n.keys) && len(n.child[i+1].keys) >= degree:
+		n.borrowFromNext(i)
+	case i < len(n.keys):
+		n.merge(i)
+	default:
+		n.merge(i - 1)
+		i--
+	}
+	return i
+}
+
+func (n *nodeB[T, D]) rm(key T, degree int, compare func(a, b T) int) bool {
+	i, found := n.search(key, compare)
+
+	if n.leaf() {
+		if !found {
+			return false
+		}
+		n.keys = slices.Delete(n.keys, i, i+1)
+		n.data = slices.Delete(n.data, i, i+1)
+		return true
+	}
+
+	if found {
+		switch {
+		case len(n.child[i].keys) >= degree:
+			pred := n.child[i].maxNode()
+			last := len(pred.keys) - 1
+			n.keys[i], n.data[i] = pred.keys[last], pred.data[last]
+			return n.child[i].rm(n.keys[i], degree, compare)
+
+		case len(n.child[i+1].keys) >= degree:
+			succ := n.child[i+1].minNode()
+			n.keys[i], n.data[i] = succ.keys[0], succ.data[0]
+			return n.child[i+1].rm(n.keys[i], degree, compare)
+
+		default:
+			n.merge(i)
+			return n.child[i].rm(key, degree, compare)
+		}
+	}
+
+	if len(n.child[i].keys) < degree {
+		i = n.fill(i, degree)
+	}
+	return n.child[i].rm(key, degree, compare)
+}
+
+// ascend - ordered walk over [lo, hi] bounds, returns false when walk must stop
+func (n *nodeB[T, D]) ascend(lo, hi *T, compare func(a, b T) int, yield func(T, D) bool) bool {
+	for i, key := range n.keys {
+		if !n.leaf() && (lo == nil || compare(*lo, key) < 0) {
+			if !n.child[i].ascend(lo, hi, compare, yield) {
+				return false
+			}
+		}
+		if hi != nil && compare(*hi, key) < 0 {
+			return false
+		}
+		if (lo == nil || compare(*lo, key) <= 0) && !yield(key, n.data[i]) {
+			return false
+		}
+	}
+
+	if !n.leaf() {
+		return n.child[len(n.keys)].ascend(lo, hi, compare, yield)
+	}
+	return true
+}
+
+func (n *nodeB[T, D]) descend(yield func(T, D) bool) bool {
+	for i := len(n.keys); i >= 0; i-- {
+		if !n.leaf() && !n.child[i].descend(yield) {
+			return false
+		}
+		if i > 0 && !yield(n.keys[i-1], n.data[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+bTree - ordered map based on B-tree.
+Every node keeps from degree-1 up to 2*degree-1 keys in flat slices,
+so lookups touch only O(log n / log degree) nodes and stay cache-friendly
+on large indexes.
+*/
+type bTree[T any, D any] struct {
+	degree int
+	size   int
+	cmp    func(a, b T) int
+	root   *nodeB[T, D]
+}
+
+// NewBTree - creates B-tree with minimal degree (must be above 1)
+func NewBTree[T constraints.Ordered, D any](degree int) *bTree[T, D] {
+	return NewBTreeFunc[T, D](degree, cmp.Compare[T])
+}
+
+// NewBTreeFunc - creates B-tree ordered by compare function
+// with the same contract as cmp.Compare
+func NewBTreeFunc[T any, D any](degree int, compare func(a, b T) int) *bTree[T, D] {
+	if degree < 2 {
+		panic(somedata.ErrBTreeInvalidDegree)
+	}
+
+	return &bTree[T, D]{degree: degree, cmp: compare}
+}
+
+func (t *bTree[T, D]) Size() int {
+	return t.size
+}
+
+func (t *bTree[T, D]) Degree() int {
+	return t.degree
+}
+
+// Get - returns data stored under key
+func (t *bTree[T, D]) Get(key T) (D, bool) {
+	node, i := t.root.find(key, t.cmp)
+	if node == nil {
+		var zero D
+		return zero, false
+	}
+	return node.data[i], true
+}
+
+// Contains - key existing in tree
+func (t *bTree[T, D]) Contains(key T) bool {
+	node, _ := t.root.find(key, t.cmp)
+	return node != nil
+}
+
+// Set - stores data under key. Returns previous data and true if key already existed
+func (t *bTree[T, D]) Set(key T, data D) (D, bool) {
+	maxKeys := 2*t.degree - 1
+
+	if t.root == nil {
+		t.root = &nodeB[T, D]{
+			keys: make([]T, 0, maxKeys),
+			data: make([]D, 0, maxKeys),
+		}
+	}
+
+	if len(t.root.keys) == maxKeys {
+		root := &nodeB[T, D]{
+			keys:  make([]T, 0, maxKeys),
+			data:  make([]D, 0, maxKeys),
+			child: make([]*nodeB[T, D], 1, maxKeys+1),
+		}
+		root.child[0] = t.root
+		root.splitChild(0, t.degree)
+		t.root = root
+	}
+
+	prev, replaced := t.root.insertNonFull(key, data, t.degree, t.cmp)
+	if !replaced {
+		t.size++
+	}
+	return prev, replaced
+}
+
+// Delete - removes key from tree
+func (t *bTree[T, D]) Delete(key T) bool {
+	if t.root == nil {
+		return false
+	}
+
+	deleted := t.root.rm(key, t.degree, t.cmp)
+	if deleted {
+		t.size--
+	}
+
+	if len(t.root.keys) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.child[0]
+		}
+	}
+
+	return deleted
+}
+
+func (t *bTree[T, D]) Min() (T, bool) {
+	if t.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return t.root.minNode().keys[0], true
+}
+
+func (t *bTree[T, D]) Max() (T, bool) {
+	if t.size == 0 {
+		var zero T
+		return zero, false
+	}
+	node := t.root.maxNode()
+	return node.keys[len(node.keys)-1], true
+}
+
+// All - iterator over keys and data in ascending order
+func (t *bTree[T, D]) All() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		if t.root != nil {
+			t.root.ascend(nil, nil, t.cmp, yield)
+		}
+	}
+}
+
+// Backward - iterator over keys and data in descending order
+func (t *bTree[T, D]) Backward() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		if t.root != nil {
+			t.root.descend(yield)
+		}
+	}
+}
+
+// Range - iterator over keys within [lo, hi] in ascending order
+func (t *bTree[T, D]) Range(lo, hi T) iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		if t.root != nil && t.cmp(lo, hi) <= 0 {
+			t.root.ascend(&lo, &hi, t.cmp, yield)
+		}
+	}
+}
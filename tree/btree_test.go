@@ -0,0 +1,158 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestBTree_SetGet(t *testing.T) {
+	tree := somedata.NewBTree[int, int](2)
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if _, replaced := tree.Set(i, i*10); replaced {
+			t.Fatalf("Set(%d): unexpected replace", i)
+		}
+	}
+
+	if tree.Size() != n {
+		t.Fatalf("expected size %d, got %d", n, tree.Size())
+	}
+
+	for i := 0; i < n; i++ {
+		data, ok := tree.Get(i)
+		if !ok || data != i*10 {
+			t.Fatalf("Get(%d): expected %d, got %d (%v)", i, i*10, data, ok)
+		}
+	}
+
+	prev, replaced := tree.Set(10, -1)
+	if !replaced || prev != 100 {
+		t.Fatalf("Set existing: expected (100, true), got (%d, %v)", prev, replaced)
+	}
+	if tree.Size() != n {
+		t.Fatalf("Set existing changed size to %d", tree.Size())
+	}
+
+	min, _ := tree.Min()
+	max, _ := tree.Max()
+	if min != 0 || max != n-1 {
+		t.Fatalf("expected min/max 0/%d, got %d/%d", n-1, min, max)
+	}
+}
+
+func TestBTree_RandomDelete(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		tree := somedata.NewBTree[int, int](degree)
+		ref := map[int]int{}
+		rnd := rand.New(rand.NewSource(int64(degree)))
+
+		for i := 0; i < 20000; i++ {
+			key := rnd.Intn(2000)
+			if rnd.Intn(2) == 0 {
+				_, exists := ref[key]
+				if tree.Delete(key) != exists {
+					t.Fatalf("degree %d: Delete(%d) expected %v", degree, key, exists)
+				}
+				delete(ref, key)
+				continue
+			}
+			tree.Set(key, i)
+			ref[key] = i
+		}
+
+		if tree.Size() != len(ref) {
+			t.Fatalf("degree %d: expected size %d, got %d", degree, len(ref), tree.Size())
+		}
+
+		expected := make([]int, 0, len(ref))
+		for k := range ref {
+			expected = append(expected, k)
+		}
+		slices.Sort(expected)
+
+		got := []int{}
+		for k, d := range tree.All() {
+			if ref[k] != d {
+				t.Fatalf("degree %d: data mismatch for key %d", degree, k)
+			}
+			got = append(got, k)
+		}
+		if !slices.Equal(got, expected) {
+			t.Fatalf("degree %d: All mismatch", degree)
+		}
+
+		got = got[:0]
+		for k := range tree.Backward() {
+			got = append(got, k)
+		}
+		slices.Reverse(expected)
+		if !slices.Equal(got, expected) {
+			t.Fatalf("degree %d: Backward mismatch", degree)
+		}
+	}
+}
+
+func TestBTree_Range(t *testing.T) {
+	tree := somedata.NewBTree[int, string](2)
+	for i := 0; i < 100; i += 2 {
+		tree.Set(i, "")
+	}
+
+	got := []int{}
+	for k := range tree.Range(11, 21) {
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{12, 14, 16, 18, 20}) {
+		t.Fatalf("Range(11, 21): got %v", got)
+	}
+
+	got = got[:0]
+	for k := range tree.Range(0, 98) {
+		if k == 6 {
+			break
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{0, 2, 4}) {
+		t.Fatalf("Range with break: got %v", got)
+	}
+}
+
+func TestBTree_InvalidDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on degree 1")
+		}
+	}()
+	somedata.NewBTree[int, int](1)
+}
+
+func TestBTree_Comparator(t *testing.T) {
+	tree := somedata.NewBTreeFunc[string, int](2, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	for i, k := range []string{"Beta", "alpha", "GAMMA", "delta", "ALPHA"} {
+		tree.Set(k, i)
+	}
+
+	if tree.Size() != 4 {
+		t.Fatalf("expected size 4, got %d", tree.Size())
+	}
+	if data, ok := tree.Get("Alpha"); !ok || data != 4 {
+		t.Fatalf("Get(Alpha): expected 4, got %d (%v)", data, ok)
+	}
+
+	got := []string{}
+	for k := range tree.All() {
+		got = append(got, strings.ToLower(k))
+	}
+	if !slices.Equal(got, []string{"alpha", "beta", "delta", "gamma"}) {
+		t.Fatalf("All: got %v", got)
+	}
+}
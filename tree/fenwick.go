@@ -0,0 +1,162 @@
+package somedata
+
+import (
+	"github.com/eterline/somedata"
+	matrix "github.com/eterline/somedata/matrix"
+)
+
+/*
+fenwick - binary indexed tree.
+Keeps prefix sums in a single slice of n elements with
+O(log n) point updates and prefix queries. Ranges are half-open [l, r).
+*/
+type fenwick[T matrix.Numeric] struct {
+	tree []T // 1-based internal indexing, tree[0] is unused
+}
+
+// NewFenwick - creates fenwick tree with n zero values
+func NewFenwick[T matrix.Numeric](n int) *fenwick[T] {
+	if n < 0 {
+		panic(somedata.ErrFenwickInvalidSize(n))
+	}
+	return &fenwick[T]{tree: make([]T, n+1)}
+}
+
+// NewFenwickFrom - creates fenwick tree over values in O(n)
+func NewFenwickFrom[T matrix.Numeric](values []T) *fenwick[T] {
+	ft := &fenwick[T]{tree: make([]T, len(values)+1)}
+	copy(ft.tree[1:], values)
+
+	for i := 1; i < len(ft.tree); i++ {
+		if parent := i + i&-i; parent < len(ft.tree) {
+			ft.tree[parent] += ft.tree[i]
+		}
+	}
+	return ft
+}
+
+func (ft *fenwick[T]) Len() int {
+	return len(ft.tree) - 1
+}
+
+// Add - adds delta to value at index i
+func (ft *fenwick[T]) Add(i int, delta T) {
+	if i < 0 || i >= ft.Len() {
+		panic(somedata.ErrFenwickOutOfRange(i, ft.Len()))
+	}
+
+	for i++; i < len(ft.tree); i += i & -i {
+		ft.tree[i] += delta
+	}
+}
+
+// PrefixSum - sum of the first i values, [0, i)
+func (ft *fenwick[T]) PrefixSum(i int) T {
+	if i < 0 || i > ft.Len() {
+		panic(somedata.ErrFenwickOutOfRange(i, ft.Len()))
+	}
+
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += ft.tree[i]
+	}
+	return sum
+}
+
+// RangeSum - sum of values in [l, r)
+func (ft *fenwick[T]) RangeSum(l, r int) T {
+	if l < 0 || r > ft.Len() || l > r {
+		panic(somedata.ErrFenwickInvalidRange(l, r, ft.Len()))
+	}
+	return ft.PrefixSum(r) - ft.PrefixSum(l)
+}
+
+// LowerBound - the smallest i with PrefixSum(i+1) >= value,
+// returns Len() if there is no such index. All values must be non-negative
+func (ft *fenwick[T]) LowerBound(value T) int {
+	var (
+		pos  = 0
+		n    = ft.Len()
+		step = 1
+	)
+
+	for step*2 <= n {
+		step *= 2
+	}
+
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next <= n && ft.tree[next] < value {
+			pos = next
+			value -= ft.tree[next]
+		}
+	}
+	return pos
+}
+
+/*
+fenwick2 - 2D binary indexed tree with
+the same width/height conventions as NewMatrix2.
+*/
+type fenwick2[T matrix.Numeric] struct {
+	width  int
+	height int
+	tree   []T // (width+1)*(height+1) flat store, 1-based internal indexing
+}
+
+// NewFenwick2 - creates 2D fenwick tree with zero values
+func NewFenwick2[T matrix.Numeric](width, height int) *fenwick2[T] {
+	if width < 1 || height < 1 {
+		panic(somedata.ErrMatNegativeCoords(2))
+	}
+
+	return &fenwick2[T]{
+		width:  width,
+		height: height,
+		tree:   make([]T, (width+1)*(height+1)),
+	}
+}
+
+func (ft *fenwick2[T]) Shape() []int {
+	return []int{ft.width, ft.height}
+}
+
+// Add - adds delta to value at (x, y)
+func (ft *fenwick2[T]) Add(x, y int, delta T) {
+	if x < 0 || x >= ft.width || y < 0 || y >= ft.height {
+		panic(somedata.ErrMatOutCoords(2))
+	}
+
+	for i := x + 1; i <= ft.width; i += i & -i {
+		row := ft.tree[i*(ft.height+1):]
+		for j := y + 1; j <= ft.height; j += j & -j {
+			row[j] += delta
+		}
+	}
+}
+
+// PrefixSum - sum of values in [0, x) × [0, y)
+func (ft *fenwick2[T]) PrefixSum(x, y int) T {
+	if x < 0 || x > ft.width || y < 0 || y > ft.height {
+		panic(somedata.ErrMatOutCoords(2))
+	}
+
+	var sum T
+	for i := x; i > 0; i -= i & -i {
+		row := ft.tree[i*(ft.height+1):]
+		for j := y; j > 0; j -= j & -j {
+			sum += row[j]
+		}
+	}
+	return sum
+}
+
+// RangeSum - sum of values in [x1, x2) × [y1, y2)
+func (ft *fenwick2[T]) RangeSum(x1, y1, x2, y2 int) T {
+	if x1 < 0 || x2 > ft.width || x1 > x2 {
+		panic(somedata.ErrFenwickInvalidRange(x1, x2, ft.width))
+	}
+	if y1 < 0 || y2 > ft.height || y1 > y2 {
+		panic(somedata.ErrFenwickInvalidRange(y1, y2, ft.height))
+	}
+	return ft.PrefixSum(x2, y2) - ft.PrefixSum(x1, y2) - ft.PrefixSum(x2, y1) + ft.PrefixSum(x1, y1)
+}
@@ -0,0 +1,93 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"testing"
+
+	root "github.com/eterline/somedata"
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestFenwick_PrefixRangeSum(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	ft := somedata.NewFenwickFrom(values)
+
+	prefix := 0
+	for i := 0; i <= len(values); i++ {
+		if got := ft.PrefixSum(i); got != prefix {
+			t.Fatalf("PrefixSum(%d): expected %d, got %d", i, prefix, got)
+		}
+		if i < len(values) {
+			prefix += values[i]
+		}
+	}
+
+	ft.Add(4, 10)
+	if got := ft.RangeSum(3, 6); got != 1+15+9 {
+		t.Fatalf("RangeSum(3, 6): expected 25, got %d", got)
+	}
+}
+
+func TestFenwick_InvalidArgs(t *testing.T) {
+	expectPanic := func(name string, expected error, fn func()) {
+		t.Helper()
+		defer func() {
+			if got := recover(); got != expected {
+				t.Fatalf("%s: expected panic %q, got %v", name, expected, got)
+			}
+		}()
+		fn()
+	}
+
+	ft := somedata.NewFenwick[int](4)
+	expectPanic("NewFenwick(-1)", root.ErrFenwickInvalidSize(-1), func() { somedata.NewFenwick[int](-1) })
+	expectPanic("RangeSum(3, 1)", root.ErrFenwickInvalidRange(3, 1, 4), func() { ft.RangeSum(3, 1) })
+	expectPanic("RangeSum(0, 5)", root.ErrFenwickInvalidRange(0, 5, 4), func() { ft.RangeSum(0, 5) })
+
+	ft2 := somedata.NewFenwick2[int](3, 4)
+	expectPanic("RangeSum(2, 0, 1, 4)", root.ErrFenwickInvalidRange(2, 1, 3), func() { ft2.RangeSum(2, 0, 1, 4) })
+	expectPanic("RangeSum(0, 3, 3, 1)", root.ErrFenwickInvalidRange(3, 1, 4), func() { ft2.RangeSum(0, 3, 3, 1) })
+	expectPanic("RangeSum(0, 0, 3, 5)", root.ErrFenwickInvalidRange(0, 5, 4), func() { ft2.RangeSum(0, 0, 3, 5) })
+}
+
+func TestFenwick_LowerBound(t *testing.T) {
+	ft := somedata.NewFenwick[float64](6)
+	for i, v := range []float64{1, 0, 2, 3, 0, 4} {
+		ft.Add(i, v)
+	}
+
+	cases := map[float64]int{0: 0, 1: 0, 1.5: 2, 3: 2, 4: 3, 6: 3, 6.5: 5, 10: 5, 11: 6}
+	for value, expected := range cases {
+		if got := ft.LowerBound(value); got != expected {
+			t.Fatalf("LowerBound(%v): expected %d, got %d", value, expected, got)
+		}
+	}
+}
+
+func TestFenwick2_RandomAgainstGrid(t *testing.T) {
+	const w, h = 13, 7
+	rnd := rand.New(rand.NewSource(5))
+
+	ft := somedata.NewFenwick2[int64](w, h)
+	grid := [w][h]int64{}
+
+	for op := 0; op < 500; op++ {
+		x, y := rnd.Intn(w), rnd.Intn(h)
+		delta := int64(rnd.Intn(11) - 5)
+		ft.Add(x, y, delta)
+		grid[x][y] += delta
+
+		x1, y1 := rnd.Intn(w+1), rnd.Intn(h+1)
+		x2, y2 := x1+rnd.Intn(w-x1+1), y1+rnd.Intn(h-y1+1)
+
+		var expected int64
+		for i := x1; i < x2; i++ {
+			for j := y1; j < y2; j++ {
+				expected += grid[i][j]
+			}
+		}
+		if got := ft.RangeSum(x1, y1, x2, y2); got != expected {
+			t.Fatalf("RangeSum(%d, %d, %d, %d): expected %d, got %d", x1, y1, x2, y2, expected, got)
+		}
+	}
+}
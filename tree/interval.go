@@ -0,0 +1,248 @@
+package somedata
+
+import (
+	"iter"
+
+	"github.com/eterline/somedata"
+	"golang.org/x/exp/constraints"
+)
+
+// Interval - closed range [Lo, Hi]
+type Interval[T constraints.Ordered] struct {
+	Lo T
+	Hi T
+}
+
+// Overlaps - intervals have at least one common point
+func (iv Interval[T]) Overlaps(lo, hi T) bool {
+	return iv.Lo <= hi && lo <= iv.Hi
+}
+
+// Contains - point lies inside of interval
+func (iv Interval[T]) Contains(point T) bool {
+	return iv.Lo <= point && point <= iv.Hi
+}
+
+func (iv Interval[T]) less(other Interval[T]) bool {
+	return iv.Lo < other.Lo || (iv.Lo == other.Lo && iv.Hi < other.Hi)
+}
+
+type nodeInterval[T constraints.Ordered, D any] struct {
+	iv     Interval[T]
+	data   D
+	max    T // the greatest Hi in subtree
+	height int
+	low    *nodeInterval[T, D]
+	hight  *nodeInterval[T, D]
+}
+
+func (n *nodeInterval[T, D]) h() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *nodeInterval[T, D]) update() {
+	n.height = max(n.low.h(), n.hight.h()) + 1
+	n.max = n.iv.Hi
+	if n.low != nil && n.low.max > n.max {
+		n.max = n.low.max
+	}
+	if n.hight != nil && n.hight.max > n.max {
+		n.max = n.hight.max
+	}
+}
+
+func (n *nodeInterval[T, D]) rotateLow() *nodeInterval[T, D] {
+	x := n.hight
+	n.hight = x.low
+	x.low = n
+	n.update()
+	x.update()
+	return x
+}
+
+func (n *nodeInterval[T, D]) rotateHight() *nodeInterval[T, D] {
+	x := n.low
+	n.low = x.hight
+	x.hight = n
+	n.update()
+	x.update()
+	return x
+}
+
+// balance - restores AVL invariant and augmented max
+func (n *nodeInterval[T, D]) balance() *nodeInterval[T, D] {
+	n.update()
+
+	switch diff := n.low.h() - n.hight.h(); {
+	case diff > 1:
+		if n.low.low.h() < n.low.hight.h() {
+			n.low = n.low.rotateLow()
+		}
+		return n.rotateHight()
+	case diff < -1:
+		if n.hight.hight.h() < n.hight.low.h() {
+			n.hight = n.hight.rotateHight()
+		}
+		return n.rotateLow()
+	}
+	return n
+}
+
+func (n *nodeInterval[T, D]) minNode() *nodeInterval[T, D] {
+	cur := n
+	for cur.low != nil {
+		cur = cur.low
+	}
+	return cur
+}
+
+func (n *nodeInterval[T, D]) rmMin() *nodeInterval[T, D] {
+	if n.low == nil {
+		return n.hight
+	}
+	n.low = n.low.rmMin()
+	return n.balance()
+}
+
+func (n *nodeInterval[T, D]) rm(iv Interval[T]) (*nodeInterval[T, D], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch {
+	case iv.less(n.iv):
+		n.low, deleted = n.low.rm(iv)
+	case n.iv.less(iv):
+		n.hight, deleted = n.hight.rm(iv)
+	default:
+		if n.low == nil {
+			return n.hight, true
+		}
+		if n.hight == nil {
+			return n.low, true
+		}
+
+		successor := n.hight.minNode()
+		successor.hight = n.hight.rmMin()
+		successor.low = n.low
+		return successor.balance(), true
+	}
+
+	if !deleted {
+		return n, false
+	}
+	return n.balance(), true
+}
+
+// overlaps - in-order walk over intervals overlapping [lo, hi]
+func (n *nodeInterval[T, D]) overlaps(lo, hi T, yield func(Interval[T], D) bool) bool {
+	if n == nil || n.max < lo {
+		return true
+	}
+
+	if !n.low.overlaps(lo, hi, yield) {
+		return false
+	}
+	if hi < n.iv.Lo {
+		return true
+	}
+	if n.iv.Overlaps(lo, hi) && !yield(n.iv, n.data) {
+		return false
+	}
+	return n.hight.overlaps(lo, hi, yield)
+}
+
+func (n *nodeInterval[T, D]) ascend(yield func(Interval[T], D) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.low.ascend(yield) && yield(n.iv, n.data) && n.hight.ascend(yield)
+}
+
+func insertNodeInterval[T constraints.Ordered, D any](node *nodeInterval[T, D], iv Interval[T], data D) (*nodeInterval[T, D], bool) {
+	if node == nil {
+		return &nodeInterval[T, D]{iv: iv, data: data, max: iv.Hi, height: 1}, true
+	}
+
+	var added bool
+	switch {
+	case iv.less(node.iv):
+		node.low, added = insertNodeInterval(node.low, iv, data)
+	case node.iv.less(iv):
+		node.hight, added = insertNodeInterval(node.hight, iv, data)
+	default:
+		node.data = data
+		return node, false
+	}
+
+	return node.balance(), added
+}
+
+/*
+intervalTree - AVL tree ordered by interval low bounds and augmented
+with the greatest high bound of every subtree. Answers overlap and
+stabbing queries in O(log n + k) where k is the count of reported intervals.
+*/
+type intervalTree[T constraints.Ordered, D any] struct {
+	size int
+	root *nodeInterval[T, D]
+}
+
+// NewIntervalTree - creates interval tree
+func NewIntervalTree[T constraints.Ordered, D any]() *intervalTree[T, D] {
+	return &intervalTree[T, D]{}
+}
+
+func (t *intervalTree[T, D]) Size() int {
+	return t.size
+}
+
+// Insert - adds interval [lo, hi] with data, data of an existing interval is replaced
+func (t *intervalTree[T, D]) Insert(lo, hi T, data D) {
+	if hi < lo {
+		panic(somedata.ErrIntervalInvalidBounds)
+	}
+
+	var added bool
+	t.root, added = insertNodeInterval(t.root, Interval[T]{Lo: lo, Hi: hi}, data)
+	if added {
+		t.size++
+	}
+}
+
+// Delete - removes interval [lo, hi]
+func (t *intervalTree[T, D]) Delete(lo, hi T) (ok bool) {
+	t.root, ok = t.root.rm(Interval[T]{Lo: lo, Hi: hi})
+	if ok {
+		t.size--
+	}
+	return ok
+}
+
+// Overlaps - iterator over intervals having common points with [lo, hi]
+func (t *intervalTree[T, D]) Overlaps(lo, hi T) iter.Seq2[Interval[T], D] {
+	return func(yield func(Interval[T], D) bool) {
+		if hi < lo {
+			return
+		}
+		t.root.overlaps(lo, hi, yield)
+	}
+}
+
+// Stab - iterator over intervals containing point
+func (t *intervalTree[T, D]) Stab(point T) iter.Seq2[Interval[T], D] {
+	return func(yield func(Interval[T], D) bool) {
+		t.root.overlaps(point, point, yield)
+	}
+}
+
+// All - iterator over intervals ordered by low and then high bounds
+func (t *intervalTree[T, D]) All() iter.Seq2[Interval[T], D] {
+	return func(yield func(Interval[T], D) bool) {
+		t.root.ascend(yield)
+	}
+}
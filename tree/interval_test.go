@@ -0,0 +1,109 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestIntervalTree_OverlapsStab(t *testing.T) {
+	tree := somedata.NewIntervalTree[int, string]()
+
+	tree.Insert(15, 20, "a")
+	tree.Insert(10, 30, "b")
+	tree.Insert(17, 19, "c")
+	tree.Insert(5, 20, "d")
+	tree.Insert(12, 15, "e")
+	tree.Insert(30, 40, "f")
+
+	if tree.Size() != 6 {
+		t.Fatalf("expected size 6, got %d", tree.Size())
+	}
+
+	got := []string{}
+	for _, d := range tree.Overlaps(21, 29) {
+		got = append(got, d)
+	}
+	if !slices.Equal(got, []string{"b"}) {
+		t.Fatalf("Overlaps(21, 29): got %v", got)
+	}
+
+	got = got[:0]
+	for _, d := range tree.Stab(15) {
+		got = append(got, d)
+	}
+	if !slices.Equal(got, []string{"d", "b", "e", "a"}) {
+		t.Fatalf("Stab(15): got %v", got)
+	}
+
+	if !tree.Delete(10, 30) || tree.Delete(10, 30) {
+		t.Fatalf("Delete(10, 30): expected true then false")
+	}
+
+	got = got[:0]
+	for _, d := range tree.Overlaps(25, 30) {
+		got = append(got, d)
+	}
+	if !slices.Equal(got, []string{"f"}) {
+		t.Fatalf("Overlaps(25, 30) after delete: got %v", got)
+	}
+}
+
+func TestIntervalTree_RandomAgainstScan(t *testing.T) {
+	tree := somedata.NewIntervalTree[int, int]()
+	ref := map[somedata.Interval[int]]int{}
+	rnd := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 3000; i++ {
+		lo := rnd.Intn(1000)
+		iv := somedata.Interval[int]{Lo: lo, Hi: lo + rnd.Intn(50)}
+		if rnd.Intn(3) == 0 {
+			_, exists := ref[iv]
+			if tree.Delete(iv.Lo, iv.Hi) != exists {
+				t.Fatalf("Delete(%v): expected %v", iv, exists)
+			}
+			delete(ref, iv)
+			continue
+		}
+		tree.Insert(iv.Lo, iv.Hi, i)
+		ref[iv] = i
+	}
+
+	if tree.Size() != len(ref) {
+		t.Fatalf("expected size %d, got %d", len(ref), tree.Size())
+	}
+
+	for q := 0; q < 200; q++ {
+		lo := rnd.Intn(1100)
+		hi := lo + rnd.Intn(30)
+
+		expected := 0
+		for iv := range ref {
+			if iv.Overlaps(lo, hi) {
+				expected++
+			}
+		}
+
+		got := 0
+		for iv, d := range tree.Overlaps(lo, hi) {
+			if !iv.Overlaps(lo, hi) || ref[iv] != d {
+				t.Fatalf("Overlaps(%d, %d): wrong interval %v", lo, hi, iv)
+			}
+			got++
+		}
+		if got != expected {
+			t.Fatalf("Overlaps(%d, %d): expected %d intervals, got %d", lo, hi, expected, got)
+		}
+	}
+}
+
+func TestIntervalTree_InvalidBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on inverted interval")
+		}
+	}()
+	somedata.NewIntervalTree[int, any]().Insert(2, 1, nil)
+}
@@ -0,0 +1,234 @@
+package somedata
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/eterline/somedata"
+	matrix "github.com/eterline/somedata/matrix"
+)
+
+// KDPoint - point stored in k-d tree with attached data
+type KDPoint[T matrix.Numeric, D any] struct {
+	Point []T
+	Data  D
+}
+
+type nodeKD[T matrix.Numeric, D any] struct {
+	KDPoint[T, D]
+	axis  int
+	low   *nodeKD[T, D]
+	hight *nodeKD[T, D]
+}
+
+func sqDistance[T matrix.Numeric](a, b []T) float64 {
+	var sum float64
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		sum += d * d
+	}
+	return sum
+}
+
+func buildKD[T matrix.Numeric, D any](points []KDPoint[T, D], depth, dim int) *nodeKD[T, D] {
+	if len(points) == 0 {
+		return nil
+	}
+
+	axis := depth % dim
+	slices.SortFunc(points, func(a, b KDPoint[T, D]) int {
+		switch {
+		case a.Point[axis] < b.Point[axis]:
+			return -1
+		case a.Point[axis] > b.Point[axis]:
+			return 1
+		}
+		return 0
+	})
+
+	mid := len(points) / 2
+	return &nodeKD[T, D]{
+		KDPoint: points[mid],
+		axis:    axis,
+		low:     buildKD(points[:mid], depth+1, dim),
+		hight:   buildKD(points[mid+1:], depth+1, dim),
+	}
+}
+
+// nearest - collects up to k closest points into best, sorted by distance
+func (n *nodeKD[T, D]) nearest(query []T, k int, best *[]kdCandidate[T, D]) {
+	if n == nil {
+		return
+	}
+
+	dist := sqDistance(query, n.Point)
+	if len(*best) < k || dist < (*best)[len(*best)-1].dist {
+		i, _ := slices.BinarySearchFunc(*best, dist, func(c kdCandidate[T, D], d float64) int {
+			if c.dist <= d {
+				return -1
+			}
+			return 1
+		})
+		*best = slices.Insert(*best, i, kdCandidate[T, D]{node: n, dist: dist})
+		if len(*best) > k {
+			*best = (*best)[:k]
+		}
+	}
+
+	diff := float64(query[n.axis]) - float64(n.Point[n.axis])
+	near, far := n.low, n.hight
+	if diff > 0 {
+		near, far = far, near
+	}
+
+	near.nearest(query, k, best)
+	if len(*best) < k || diff*diff < (*best)[len(*best)-1].dist {
+		far.nearest(query, k, best)
+	}
+}
+
+func (n *nodeKD[T, D]) inBox(lo, hi []T, yield func(KDPoint[T, D]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	inside := true
+	for i, v := range n.Point {
+		if v < lo[i] || v > hi[i] {
+			inside = false
+			break
+		}
+	}
+	if inside && !yield(n.KDPoint) {
+		return false
+	}
+
+	v := n.Point[n.axis]
+	if lo[n.axis] <= v && !n.low.inBox(lo, hi, yield) {
+		return false
+	}
+	if v <= hi[n.axis] && !n.hight.inBox(lo, hi, yield) {
+		return false
+	}
+	return true
+}
+
+type kdCandidate[T matrix.Numeric, D any] struct {
+	node *nodeKD[T, D]
+	dist float64
+}
+
+/*
+kdTree - static k-d tree for spatial lookups over k-dimensional points.
+Built once from a point set in O(n log² n), nearest neighbour queries
+take O(log n) on average. Distances are Euclidean.
+*/
+type kdTree[T matrix.Numeric, D any] struct {
+	dim  int
+	size int
+	root *nodeKD[T, D]
+}
+
+// NewKDTree - builds k-d tree of dim-dimensional points with attached data,
+// data may be nil, otherwise it must have the same length as points
+func NewKDTree[T matrix.Numeric, D any](dim int, points [][]T, data []D) *kdTree[T, D] {
+	if dim < 1 {
+		panic(somedata.ErrKDDimMismatch(dim, 0))
+	}
+	if data != nil && len(data) != len(points) {
+		panic(somedata.ErrKDLengthMismatch)
+	}
+
+	items := make([]KDPoint[T, D], len(points))
+	for i, p := range points {
+		if len(p) != dim {
+			panic(somedata.ErrKDDimMismatch(dim, len(p)))
+		}
+		items[i].Point = slices.Clone(p)
+		if data != nil {
+			items[i].Data = data[i]
+		}
+	}
+
+	return &kdTree[T, D]{
+		dim:  dim,
+		size: len(points),
+		root: buildKD(items, 0, dim),
+	}
+}
+
+// NewKDTreeFromMatrix - builds k-d tree from rows of 2D matrix,
+// every row is a point and its data is the row index
+func NewKDTreeFromMatrix[T matrix.Numeric](m matrix.Matrix[T]) *kdTree[T, int] {
+	if m.Rank() != 2 {
+		panic(somedata.ErrMatDimCoordMismatch(2))
+	}
+
+	var (
+		shape  = m.Shape()
+		width  = shape[0]
+		height = shape[1]
+		points = make([][]T, height)
+		data   = make([]int, height)
+	)
+
+	for y := range height {
+		points[y] = make([]T, width)
+		for x := range width {
+			points[y][x] = m.Get(x, y)
+		}
+		data[y] = y
+	}
+	return NewKDTree(width, points, data)
+}
+
+func (t *kdTree[T, D]) Size() int {
+	return t.size
+}
+
+func (t *kdTree[T, D]) Dim() int {
+	return t.dim
+}
+
+func (t *kdTree[T, D]) checkDim(p []T) {
+	if len(p) != t.dim {
+		panic(somedata.ErrKDDimMismatch(t.dim, len(p)))
+	}
+}
+
+// Nearest - the closest point to query
+func (t *kdTree[T, D]) Nearest(query []T) (KDPoint[T, D], bool) {
+	found := t.KNearest(query, 1)
+	if len(found) == 0 {
+		return KDPoint[T, D]{}, false
+	}
+	return found[0], true
+}
+
+// KNearest - up to k closest points to query ordered by distance
+func (t *kdTree[T, D]) KNearest(query []T, k int) []KDPoint[T, D] {
+	t.checkDim(query)
+	k = min(k, t.size)
+	if k < 1 {
+		return nil
+	}
+
+	best := make([]kdCandidate[T, D], 0, k+1)
+	t.root.nearest(query, k, &best)
+
+	found := make([]KDPoint[T, D], len(best))
+	for i, c := range best {
+		found[i] = c.node.KDPoint
+	}
+	return found
+}
+
+// Range - iterator over points inside of axis-aligned box [lo, hi]
+func (t *kdTree[T, D]) Range(lo, hi []T) iter.Seq[KDPoint[T, D]] {
+	t.checkDim(lo)
+	t.checkDim(hi)
+
+	return func(yield func(KDPoint[T, D]) bool) {
+		t.root.inBox(lo, hi, yield)
+	}
+}
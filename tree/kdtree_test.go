@@ -0,0 +1,99 @@
+package somedata_test
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	matrix "github.com/eterline/somedata/matrix"
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func sqDist(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return sum
+}
+
+func TestKDTree_NearestAgainstScan(t *testing.T) {
+	rnd := rand.New(rand.NewSource(17))
+
+	points := make([][]float64, 500)
+	for i := range points {
+		points[i] = []float64{rnd.Float64() * 100, rnd.Float64() * 100, rnd.Float64() * 100}
+	}
+	tree := somedata.NewKDTree[float64, int](3, points, nil)
+
+	for q := 0; q < 100; q++ {
+		query := []float64{rnd.Float64() * 100, rnd.Float64() * 100, rnd.Float64() * 100}
+
+		dists := make([]float64, len(points))
+		for i, p := range points {
+			dists[i] = sqDist(query, p)
+		}
+		sort.Float64s(dists)
+
+		nearest, ok := tree.Nearest(query)
+		if !ok || sqDist(query, nearest.Point) != dists[0] {
+			t.Fatalf("Nearest: expected distance %v, got %v", dists[0], sqDist(query, nearest.Point))
+		}
+
+		found := tree.KNearest(query, 5)
+		if len(found) != 5 {
+			t.Fatalf("KNearest: expected 5 points, got %d", len(found))
+		}
+		for i, p := range found {
+			if sqDist(query, p.Point) != dists[i] {
+				t.Fatalf("KNearest[%d]: expected distance %v, got %v", i, dists[i], sqDist(query, p.Point))
+			}
+		}
+	}
+}
+
+func TestKDTree_Range(t *testing.T) {
+	points := [][]int{{1, 1}, {2, 5}, {3, 3}, {5, 2}, {6, 6}, {4, 4}, {0, 7}}
+	data := []string{"a", "b", "c", "d", "e", "f", "g"}
+	tree := somedata.NewKDTree(2, points, data)
+
+	got := []string{}
+	for p := range tree.Range([]int{2, 2}, []int{5, 5}) {
+		got = append(got, p.Data)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"b", "c", "d", "f"}) {
+		t.Fatalf("Range: got %v", got)
+	}
+}
+
+func TestKDTree_KNearestAboveSize(t *testing.T) {
+	tree := somedata.NewKDTree[int, int](2, [][]int{{0, 0}, {3, 4}, {1, 1}}, nil)
+
+	for _, k := range []int{4, 1 << 62, math.MaxInt} {
+		if found := tree.KNearest([]int{0, 0}, k); len(found) != 3 || !slices.Equal(found[2].Point, []int{3, 4}) {
+			t.Fatalf("KNearest(%d): expected all 3 points, got %v", k, found)
+		}
+	}
+}
+
+func TestKDTree_FromMatrix(t *testing.T) {
+	m := matrix.NewMatrix2[int](2, 3)
+	for y, row := range [][]int{{0, 0}, {10, 10}, {5, 1}} {
+		for x, v := range row {
+			m.Set(v, x, y)
+		}
+	}
+
+	tree := somedata.NewKDTreeFromMatrix(m)
+	if tree.Size() != 3 || tree.Dim() != 2 {
+		t.Fatalf("expected 3 points of dim 2, got %d of dim %d", tree.Size(), tree.Dim())
+	}
+
+	nearest, _ := tree.Nearest([]int{4, 2})
+	if nearest.Data != 2 || !slices.Equal(nearest.Point, []int{5, 1}) {
+		t.Fatalf("Nearest: expected row 2, got %d %v", nearest.Data, nearest.Point)
+	}
+}
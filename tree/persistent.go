@@ -0,0 +1,283 @@
+package somedata
+
+import (
+	"cmp"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// nodePersistent - immutable AVL node, never modified after creation
+type nodePersistent[T any, D any] struct {
+	value  T
+	data   D
+	height int
+	low    *nodePersistent[T, D]
+	hight  *nodePersistent[T, D]
+}
+
+func (n *nodePersistent[T, D]) h() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *nodePersistent[T, D]) find(value T, compare func(a, b T) int) *nodePersistent[T, D] {
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c < 0:
+			cur = cur.low
+		case c > 0:
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+	return nil
+}
+
+func (n *nodePersistent[T, D]) ascend(lo, hi *T, compare func(a, b T) int, yield func(T, D) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	if lo == nil || compare(*lo, n.value) < 0 {
+		if !n.low.ascend(lo, hi, compare, yield) {
+			return false
+		}
+	}
+	if (lo == nil || compare(*lo, n.value) <= 0) && (hi == nil || compare(n.value, *hi) <= 0) {
+		if !yield(n.value, n.data) {
+			return false
+		}
+	}
+	if hi == nil || compare(n.value, *hi) < 0 {
+		return n.hight.ascend(lo, hi, compare, yield)
+	}
+	return true
+}
+
+func (n *nodePersistent[T, D]) descend(yield func(T, D) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.hight.descend(yield) && yield(n.value, n.data) && n.low.descend(yield)
+}
+
+func mkNodePersistent[T any, D any](value T, data D, low, hight *nodePersistent[T, D]) *nodePersistent[T, D] {
+	return &nodePersistent[T, D]{
+		value:  value,
+		data:   data,
+		height: max(low.h(), hight.h()) + 1,
+		low:    low,
+		hight:  hight,
+	}
+}
+
+// balancePersistent - builds new balanced node from value and two subtrees
+// which heights differ at most by 2
+func balancePersistent[T any, D any](value T, data D, low, hight *nodePersistent[T, D]) *nodePersistent[T, D] {
+	switch {
+	case low.h() > hight.h()+1:
+		if low.low.h() >= low.hight.h() {
+			return mkNodePersistent(low.value, low.data,
+				low.low,
+				mkNodePersistent(value, data, low.hight, hight),
+			)
+		}
+		mid := low.hight
+		return mkNodePersistent(mid.value, mid.data,
+			mkNodePersistent(low.value, low.data, low.low, mid.low),
+			mkNodePersistent(value, data, mid.hight, hight),
+		)
+
+	case hight.h() > low.h()+1:
+		if hight.hight.h() >= hight.low.h() {
+			return mkNodePersistent(hight.value, hight.data,
+				mkNodePersistent(value, data, low, hight.low),
+				hight.hight,
+			)
+		}
+		mid := hight.low
+		return mkNodePersistent(mid.value, mid.data,
+			mkNodePersistent(value, data, low, mid.low),
+			mkNodePersistent(hight.value, hight.data, mid.hight, hight.hight),
+		)
+	}
+
+	return mkNodePersistent(value, data, low, hight)
+}
+
+func insertNodePersistent[T any, D any](node *nodePersistent[T, D], value T, data D, compare func(a, b T) int) (*nodePersistent[T, D], bool) {
+	if node == nil {
+		return mkNodePersistent[T, D](value, data, nil, nil), true
+	}
+
+	switch c := compare(value, node.value); {
+	case c < 0:
+		low, added := insertNodePersistent(node.low, value, data, compare)
+		return balancePersistent(node.value, node.data, low, node.hight), added
+	case c > 0:
+		hight, added := insertNodePersistent(node.hight, value, data, compare)
+		return balancePersistent(node.value, node.data, node.low, hight), added
+	default:
+		return mkNodePersistent(value, data, node.low, node.hight), false
+	}
+}
+
+func rmMinPersistent[T any, D any](node *nodePersistent[T, D]) *nodePersistent[T, D] {
+	if node.low == nil {
+		return node.hight
+	}
+	return balancePersistent(node.value, node.data, rmMinPersistent(node.low), node.hight)
+}
+
+func rmNodePersistent[T any, D any](node *nodePersistent[T, D], value T, compare func(a, b T) int) (*nodePersistent[T, D], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	switch c := compare(value, node.value); {
+	case c < 0:
+		low, deleted := rmNodePersistent(node.low, value, compare)
+		if !deleted {
+			return node, false
+		}
+		return balancePersistent(node.value, node.data, low, node.hight), true
+
+	case c > 0:
+		hight, deleted := rmNodePersistent(node.hight, value, compare)
+		if !deleted {
+			return node, false
+		}
+		return balancePersistent(node.value, node.data, node.low, hight), true
+	}
+
+	if node.low == nil {
+		return node.hight, true
+	}
+	if node.hight == nil {
+		return node.low, true
+	}
+
+	successor := node.hight
+	for successor.low != nil {
+		successor = successor.low
+	}
+	return balancePersistent(successor.value, successor.data, node.low, rmMinPersistent(node.hight)), true
+}
+
+/*
+persistentTree - immutable AVL ordered map.
+Insert and Delete never modify the receiver: they return a new version
+that shares all untouched nodes with the old one (path copying, O(log n)
+new nodes per operation). Any version is safe for concurrent readers
+without locking.
+*/
+type persistentTree[T any, D any] struct {
+	size int
+	cmp  func(a, b T) int
+	root *nodePersistent[T, D]
+}
+
+// NewPersistentTree - creates empty persistent tree version
+func NewPersistentTree[T constraints.Ordered, D any]() *persistentTree[T, D] {
+	return &persistentTree[T, D]{cmp: cmp.Compare[T]}
+}
+
+// NewPersistentTreeFunc - creates empty persistent tree version ordered
+// by compare function with the same contract as cmp.Compare
+func NewPersistentTreeFunc[T any, D any](compare func(a, b T) int) *persistentTree[T, D] {
+	return &persistentTree[T, D]{cmp: compare}
+}
+
+func (t *persistentTree[T, D]) Size() int {
+	return t.size
+}
+
+// Insert - returns new version with value stored with data
+func (t *persistentTree[T, D]) Insert(value T, data D) *persistentTree[T, D] {
+	root, added := insertNodePersistent(t.root, value, data, t.cmp)
+
+	next := &persistentTree[T, D]{size: t.size, cmp: t.cmp, root: root}
+	if added {
+		next.size++
+	}
+	return next
+}
+
+// Delete - returns new version without value, or receiver itself if value is missing
+func (t *persistentTree[T, D]) Delete(value T) (*persistentTree[T, D], bool) {
+	root, deleted := rmNodePersistent(t.root, value, t.cmp)
+	if !deleted {
+		return t, false
+	}
+	return &persistentTree[T, D]{size: t.size - 1, cmp: t.cmp, root: root}, true
+}
+
+// Get - returns data stored under value
+func (t *persistentTree[T, D]) Get(value T) (D, bool) {
+	node := t.root.find(value, t.cmp)
+	if node == nil {
+		var zero D
+		return zero, false
+	}
+	return node.data, true
+}
+
+// Contains - value existing in tree version
+func (t *persistentTree[T, D]) Contains(value T) bool {
+	return t.root.find(value, t.cmp) != nil
+}
+
+func (t *persistentTree[T, D]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	cur := t.root
+	for cur.low != nil {
+		cur = cur.low
+	}
+	return cur.value, true
+}
+
+func (t *persistentTree[T, D]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	cur := t.root
+	for cur.hight != nil {
+		cur = cur.hight
+	}
+	return cur.value, true
+}
+
+// All - iterator over values and data in ascending order
+func (t *persistentTree[T, D]) All() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		t.root.ascend(nil, nil, t.cmp, yield)
+	}
+}
+
+// Backward - iterator over values and data in descending order
+func (t *persistentTree[T, D]) Backward() iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		t.root.descend(yield)
+	}
+}
+
+// Range - iterator over values within [lo, hi] in ascending order
+func (t *persistentTree[T, D]) Range(lo, hi T) iter.Seq2[T, D] {
+	return func(yield func(T, D) bool) {
+		if t.cmp(hi, lo) < 0 {
+			return
+		}
+		t.root.ascend(&lo, &hi, t.cmp, yield)
+	}
+}
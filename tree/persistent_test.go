@@ -0,0 +1,90 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestPersistentTree_VersionsAreIndependent(t *testing.T) {
+	v0 := somedata.NewPersistentTree[int, string]()
+	v1 := v0.Insert(1, "a").Insert(2, "b").Insert(3, "c")
+	v2 := v1.Insert(2, "B")
+	v3, ok := v2.Delete(1)
+	if !ok {
+		t.Fatalf("Delete(1): expected true")
+	}
+
+	if v0.Size() != 0 || v1.Size() != 3 || v2.Size() != 3 || v3.Size() != 2 {
+		t.Fatalf("unexpected sizes %d %d %d %d", v0.Size(), v1.Size(), v2.Size(), v3.Size())
+	}
+
+	if data, _ := v1.Get(2); data != "b" {
+		t.Fatalf("v1.Get(2): expected b, got %s", data)
+	}
+	if data, _ := v2.Get(2); data != "B" {
+		t.Fatalf("v2.Get(2): expected B, got %s", data)
+	}
+	if !v2.Contains(1) || v3.Contains(1) {
+		t.Fatalf("Delete must not affect previous version")
+	}
+
+	same, ok := v3.Delete(42)
+	if ok || same != v3 {
+		t.Fatalf("Delete of missing value must return the same version")
+	}
+}
+
+func TestPersistentTree_RandomHistory(t *testing.T) {
+	rnd := rand.New(rand.NewSource(9))
+
+	tree := somedata.NewPersistentTree[int, int]()
+	ref := map[int]int{}
+
+	history := [][]int{}
+	trees := []func() []int{}
+
+	for i := 0; i < 2000; i++ {
+		key := rnd.Intn(300)
+		if rnd.Intn(3) == 0 {
+			tree, _ = tree.Delete(key)
+			delete(ref, key)
+		} else {
+			tree = tree.Insert(key, i)
+			ref[key] = i
+		}
+
+		if i%100 == 0 {
+			keys := make([]int, 0, len(ref))
+			for k := range ref {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			history = append(history, keys)
+
+			version := tree
+			trees = append(trees, func() []int {
+				got := []int{}
+				for k := range version.All() {
+					got = append(got, k)
+				}
+				return got
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range trees {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := trees[i](); !slices.Equal(got, history[i]) {
+				t.Errorf("version %d: keys mismatch", i)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,269 @@
+package somedata
+
+import (
+	"iter"
+	"sort"
+	"strings"
+)
+
+// RadixKey - key types supported by radix tree
+type RadixKey interface {
+	~string | ~[]byte
+}
+
+type nodeRadix[D any] struct {
+	prefix string // edge label from parent
+	leaf   bool   // node keeps a value
+	data   D
+	edges  []*nodeRadix[D] // sorted by first label byte
+}
+
+func (n *nodeRadix[D]) edge(b byte) (int, bool) {
+	i := sort.Search(len(n.edges), func(i int) bool {
+		return n.edges[i].prefix[0] >= b
+	})
+	return i, i < len(n.edges) && n.edges[i].prefix[0] == b
+}
+
+func (n *nodeRadix[D]) addEdge(child *nodeRadix[D]) {
+	i, _ := n.edge(child.prefix[0])
+	n.edges = append(n.edges, nil)
+	copy(n.edges[i+1:], n.edges[i:])
+	n.edges[i] = child
+}
+
+// mergeChild - glues node with its single child when node has no value
+func (n *nodeRadix[D]) mergeChild() {
+	child := n.edges[0]
+	n.prefix += child.prefix
+	n.leaf = child.leaf
+	n.data = child.data
+	n.edges = child.edges
+}
+
+func (n *nodeRadix[D]) rm(search string) (D, bool) {
+	var zero D
+
+	if len(search) == 0 {
+		if !n.leaf {
+			return zero, false
+		}
+		prev := n.data
+		n.leaf = false
+		n.data = zero
+		return prev, true
+	}
+
+	i, ok := n.edge(search[0])
+	if !ok || !strings.HasPrefix(search, n.edges[i].prefix) {
+		return zero, false
+	}
+
+	child := n.edges[i]
+	prev, deleted := child.rm(search[len(child.prefix):])
+	if !deleted || child.leaf {
+		return prev, deleted
+	}
+
+	switch len(child.edges) {
+	case 0:
+		n.edges = append(n.edges[:i], n.edges[i+1:]...)
+	case 1:
+		child.mergeChild()
+	}
+
+	return prev, true
+}
+
+// walk - pre-order walk in lexicographic key order
+func (n *nodeRadix[D]) walk(key string, yield func(string, D) bool) bool {
+	if n.leaf && !yield(key, n.data) {
+		return false
+	}
+	for _, child := range n.edges {
+		if !child.walk(key+child.prefix, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+/*
+radixTree - compressed prefix tree.
+Chains of single-child nodes are merged into one edge label, so
+lookup cost depends on key length, not on the count of stored keys.
+*/
+type radixTree[K RadixKey, D any] struct {
+	size int
+	root *nodeRadix[D]
+}
+
+// NewRadixTree - creates radix tree
+func NewRadixTree[K RadixKey, D any]() *radixTree[K, D] {
+	return &radixTree[K, D]{root: &nodeRadix[D]{}}
+}
+
+func (t *radixTree[K, D]) Size() int {
+	return t.size
+}
+
+// Insert - stores data under key. Returns previous data and true if key already existed
+func (t *radixTree[K, D]) Insert(key K, data D) (D, bool) {
+	search := string(key)
+	cur := t.root
+
+	for {
+		if len(search) == 0 {
+			prev, existed := cur.data, cur.leaf
+			cur.leaf = true
+			cur.data = data
+			if !existed {
+				t.size++
+			}
+			return prev, existed
+		}
+
+		i, ok := cur.edge(search[0])
+		if !ok {
+			cur.addEdge(&nodeRadix[D]{prefix: search, leaf: true, data: data})
+			t.size++
+			var zero D
+			return zero, false
+		}
+
+		child := cur.edges[i]
+		common := commonPrefixLen(search, child.prefix)
+		if common == len(child.prefix) {
+			cur = child
+			search = search[common:]
+			continue
+		}
+
+		// split edge by common part
+		mid := &nodeRadix[D]{prefix: search[:common]}
+		child.prefix = child.prefix[common:]
+		mid.edges = []*nodeRadix[D]{child}
+		cur.edges[i] = mid
+
+		cur = mid
+		search = search[common:]
+	}
+}
+
+// Get - returns data stored under key
+func (t *radixTree[K, D]) Get(key K) (D, bool) {
+	search := string(key)
+	cur := t.root
+
+	for len(search) != 0 {
+		i, ok := cur.edge(search[0])
+		if !ok || !strings.HasPrefix(search, cur.edges[i].prefix) {
+			var zero D
+			return zero, false
+		}
+		cur = cur.edges[i]
+		search = search[len(cur.prefix):]
+	}
+
+	return cur.data, cur.leaf
+}
+
+// Delete - removes key. Returns removed data and true if key existed
+func (t *radixTree[K, D]) Delete(key K) (D, bool) {
+	prev, deleted := t.root.rm(string(key))
+	if deleted {
+		t.size--
+	}
+	return prev, deleted
+}
+
+// LongestPrefix - the longest stored key which is a prefix of key
+func (t *radixTree[K, D]) LongestPrefix(key K) (K, D, bool) {
+	var (
+		search  = string(key)
+		cur     = t.root
+		matched = 0
+		found   *nodeRadix[D]
+		foundAt int
+	)
+
+	for {
+		if cur.leaf {
+			found, foundAt = cur, matched
+		}
+		if len(search) == 0 {
+			break
+		}
+
+		i, ok := cur.edge(search[0])
+		if !ok || !strings.HasPrefix(search, cur.edges[i].prefix) {
+			break
+		}
+		cur = cur.edges[i]
+		search = search[len(cur.prefix):]
+		matched += len(cur.prefix)
+	}
+
+	if found == nil {
+		var (
+			zeroK K
+			zeroD D
+		)
+		return zeroK, zeroD, false
+	}
+	return K(string(key)[:foundAt]), found.data, true
+}
+
+// WalkPrefix - iterator over keys starting with prefix in lexicographic order
+func (t *radixTree[K, D]) WalkPrefix(prefix K) iter.Seq2[K, D] {
+	return func(yield func(K, D) bool) {
+		var (
+			search = string(prefix)
+			cur    = t.root
+			key    = ""
+		)
+
+		for len(search) != 0 {
+			i, ok := cur.edge(search[0])
+			if !ok {
+				return
+			}
+
+			child := cur.edges[i]
+			switch {
+			case strings.HasPrefix(search, child.prefix):
+				search = search[len(child.prefix):]
+			case strings.HasPrefix(child.prefix, search):
+				search = ""
+			default:
+				return
+			}
+
+			key += child.prefix
+			cur = child
+		}
+
+		cur.walk(key, func(k string, d D) bool {
+			return yield(K(k), d)
+		})
+	}
+}
+
+// All - iterator over all keys in lexicographic order
+func (t *radixTree[K, D]) All() iter.Seq2[K, D] {
+	return func(yield func(K, D) bool) {
+		t.root.walk("", func(k string, d D) bool {
+			return yield(K(k), d)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestRadixTree_InsertGetDelete(t *testing.T) {
+	tree := somedata.NewRadixTree[string, int]()
+
+	keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "r", ""}
+	for i, k := range keys {
+		if _, existed := tree.Insert(k, i); existed {
+			t.Fatalf("Insert(%q): unexpected existing key", k)
+		}
+	}
+
+	if tree.Size() != len(keys) {
+		t.Fatalf("expected size %d, got %d", len(keys), tree.Size())
+	}
+
+	for i, k := range keys {
+		if data, ok := tree.Get(k); !ok || data != i {
+			t.Fatalf("Get(%q): expected %d, got %d (%v)", k, i, data, ok)
+		}
+	}
+
+	for _, k := range []string{"rom", "roman", "rubicons", "x"} {
+		if _, ok := tree.Get(k); ok {
+			t.Fatalf("Get(%q): expected missing key", k)
+		}
+	}
+
+	if prev, ok := tree.Delete("rubens"); !ok || prev != 3 {
+		t.Fatalf("Delete(rubens): expected (3, true), got (%d, %v)", prev, ok)
+	}
+	if _, ok := tree.Delete("rube"); ok {
+		t.Fatalf("Delete(rube): expected missing key")
+	}
+	if data, ok := tree.Get("ruber"); !ok || data != 4 {
+		t.Fatalf("Get(ruber) after delete: expected 4, got %d", data)
+	}
+
+	got := []string{}
+	for k := range tree.All() {
+		got = append(got, k)
+	}
+	expected := []string{"", "r", "romane", "romanus", "romulus", "ruber", "rubicon", "rubicundus"}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("All: expected %v, got %v", expected, got)
+	}
+}
+
+func TestRadixTree_LongestPrefixWalkPrefix(t *testing.T) {
+	tree := somedata.NewRadixTree[[]byte, string]()
+
+	for _, k := range []string{"/api", "/api/v1", "/api/v1/users", "/static", "/api/v2"} {
+		tree.Insert([]byte(k), k)
+	}
+
+	key, data, ok := tree.LongestPrefix([]byte("/api/v1/users/42"))
+	if !ok || string(key) != "/api/v1/users" || data != "/api/v1/users" {
+		t.Fatalf("LongestPrefix: got %q (%v)", key, ok)
+	}
+
+	key, _, ok = tree.LongestPrefix([]byte("/api/v3"))
+	if !ok || string(key) != "/api" {
+		t.Fatalf("LongestPrefix(/api/v3): got %q (%v)", key, ok)
+	}
+
+	if _, _, ok = tree.LongestPrefix([]byte("/home")); ok {
+		t.Fatalf("LongestPrefix(/home): expected no match")
+	}
+
+	got := []string{}
+	for k := range tree.WalkPrefix([]byte("/api/v")) {
+		got = append(got, string(k))
+	}
+	if !slices.Equal(got, []string{"/api/v1", "/api/v1/users", "/api/v2"}) {
+		t.Fatalf("WalkPrefix(/api/v): got %v", got)
+	}
+
+	for range tree.WalkPrefix([]byte("/apix")) {
+		t.Fatalf("WalkPrefix(/apix): expected empty sequence")
+	}
+}
+
+func TestRadixTree_RandomAgainstMap(t *testing.T) {
+	tree := somedata.NewRadixTree[string, int]()
+	ref := map[string]int{}
+	rnd := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 5000; i++ {
+		key := strconv.FormatInt(int64(rnd.Intn(3000)), 4)
+		if rnd.Intn(3) == 0 {
+			_, exists := ref[key]
+			if _, ok := tree.Delete(key); ok != exists {
+				t.Fatalf("Delete(%q): expected %v", key, exists)
+			}
+			delete(ref, key)
+			continue
+		}
+		tree.Insert(key, i)
+		ref[key] = i
+	}
+
+	if tree.Size() != len(ref) {
+		t.Fatalf("expected size %d, got %d", len(ref), tree.Size())
+	}
+
+	got := []string{}
+	for k, d := range tree.All() {
+		if ref[k] != d {
+			t.Fatalf("data mismatch for %q", k)
+		}
+		got = append(got, k)
+	}
+	if !slices.IsSorted(got) || len(got) != len(ref) {
+		t.Fatalf("All: expected %d sorted keys, got %d", len(ref), len(got))
+	}
+}
@@ -0,0 +1,282 @@
+package somedata
+
+import (
+	"cmp"
+
+	"github.com/eterline/somedata"
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	rbRed   = true
+	rbBlack = false
+)
+
+type nodeRB[T any, D any] struct {
+	value T
+	data  D
+	red   bool
+	low   *nodeRB[T, D]
+	hight *nodeRB[T, D]
+}
+
+func (n *nodeRB[T, D]) Value() T {
+	if n == nil {
+		panic(somedata.ErrNilBstNode)
+	}
+	return n.value
+}
+
+func (n *nodeRB[T, D]) Data() D {
+	if n == nil {
+		panic(somedata.ErrNilBstNode)
+	}
+	return n.data
+}
+
+func (n *nodeRB[T, D]) isRed() bool {
+	return n != nil && n.red
+}
+
+func (n *nodeRB[T, D]) rotateLow() *nodeRB[T, D] {
+	x := n.hight
+	n.hight = x.low
+	x.low = n
+	x.red = n.red
+	n.red = rbRed
+	return x
+}
+
+func (n *nodeRB[T, D]) rotateHight() *nodeRB[T, D] {
+	x := n.low
+	n.low = x.hight
+	x.hight = n
+	x.red = n.red
+	n.red = rbRed
+	return x
+}
+
+func (n *nodeRB[T, D]) flipColors() {
+	n.red = !n.red
+	n.low.red = !n.low.red
+	n.hight.red = !n.hight.red
+}
+
+// fixUp - restores left-leaning invariants on the way up
+func (n *nodeRB[T, D]) fixUp() *nodeRB[T, D] {
+	if n.hight.isRed() && !n.low.isRed() {
+		n = n.rotateLow()
+	}
+	if n.low.isRed() && n.low.low.isRed() {
+		n = n.rotateHight()
+	}
+	if n.low.isRed() && n.hight.isRed() {
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *nodeRB[T, D]) moveRedLow() *nodeRB[T, D] {
+	n.flipColors()
+	if n.hight.low.isRed() {
+		n.hight = n.hight.rotateHight()
+		n = n.rotateLow()
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *nodeRB[T, D]) moveRedHight() *nodeRB[T, D] {
+	n.flipColors()
+	if n.low.low.isRed() {
+		n = n.rotateHight()
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *nodeRB[T, D]) minNode() *nodeRB[T, D] {
+	cur := n
+	for cur.low != nil {
+		cur = cur.low
+	}
+	return cur
+}
+
+func (n *nodeRB[T, D]) maxNode() *nodeRB[T, D] {
+	cur := n
+	for cur.hight != nil {
+		cur = cur.hight
+	}
+	return cur
+}
+
+func (n *nodeRB[T, D]) find(value T, compare func(a, b T) int) *nodeRB[T, D] {
+	cur := n
+	for cur != nil {
+		switch c := compare(value, cur.value); {
+		case c < 0:
+			cur = cur.low
+		case c > 0:
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+	return nil
+}
+
+func (n *nodeRB[T, D]) inOrder(fn func(T)) {
+	if n == nil {
+		return
+	}
+
+	n.low.inOrder(fn)
+	fn(n.value)
+	n.hight.inOrder(fn)
+}
+
+func (n *nodeRB[T, D]) rmMin() *nodeRB[T, D] {
+	if n.low == nil {
+		return nil
+	}
+	if !n.low.isRed() && !n.low.low.isRed() {
+		n = n.moveRedLow()
+	}
+	n.low = n.low.rmMin()
+	return n.fixUp()
+}
+
+// rm - removes value from subtree, caller must ensure that value exists
+func (n *nodeRB[T, D]) rm(value T, compare func(a, b T) int) *nodeRB[T, D] {
+	if compare(value, n.value) < 0 {
+		if !n.low.isRed() && !n.low.low.isRed() {
+			n = n.moveRedLow()
+		}
+		n.low = n.low.rm(value, compare)
+		return n.fixUp()
+	}
+
+	if n.low.isRed() {
+		n = n.rotateHight()
+	}
+	if compare(value, n.value) == 0 && n.hight == nil {
+		return nil
+	}
+	if !n.hight.isRed() && !n.hight.low.isRed() {
+		n = n.moveRedHight()
+	}
+	if compare(value, n.value) == 0 {
+		successor := n.hight.minNode()
+		n.value = successor.value
+		n.data = successor.data
+		n.hight = n.hight.rmMin()
+	} else {
+		n.hight = n.hight.rm(value, compare)
+	}
+	return n.fixUp()
+}
+
+func insertNodeRB[T any, D any](node *nodeRB[T, D], value T, data D, compare func(a, b T) int) (*nodeRB[T, D], bool) {
+	if node == nil {
+		return &nodeRB[T, D]{value: value, data: data, red: rbRed}, true
+	}
+
+	var added bool
+	switch c := compare(value, node.value); {
+	case c < 0:
+		node.low, added = insertNodeRB(node.low, value, data, compare)
+	case c > 0:
+		node.hight, added = insertNodeRB(node.hight, value, data, compare)
+	default:
+		node.data = data
+	}
+
+	return node.fixUp(), added
+}
+
+/*
+rbTree - left-leaning red-black tree.
+Keeps the same surface as threeBST but stays balanced
+on any insertion order, so all operations are O(log n).
+*/
+type rbTree[T any, D any] struct {
+	size int
+	cmp  func(a, b T) int
+	root *nodeRB[T, D]
+}
+
+// NewRBTree - creates self-balancing red-black tree
+func NewRBTree[T constraints.Ordered, D any]() *rbTree[T, D] {
+	return &rbTree[T, D]{cmp: cmp.Compare[T]}
+}
+
+// NewRBTreeFunc - creates red-black tree ordered by compare function
+// with the same contract as cmp.Compare
+func NewRBTreeFunc[T any, D any](compare func(a, b T) int) *rbTree[T, D] {
+	return &rbTree[T, D]{cmp: compare}
+}
+
+func (t *rbTree[T, D]) Size() int {
+	return t.size
+}
+
+// Insert - adds value with data, data of an existing value is replaced
+func (t *rbTree[T, D]) Insert(value T, data D) {
+	var added bool
+	t.root, added = insertNodeRB(t.root, value, data, t.cmp)
+	t.root.red = rbBlack
+	if added {
+		t.size++
+	}
+}
+
+// Get - returns data stored under value
+func (t *rbTree[T, D]) Get(value T) (D, bool) {
+	node := t.root.find(value, t.cmp)
+	if node == nil {
+		var zero D
+		return zero, false
+	}
+	return node.data, true
+}
+
+func (t *rbTree[T, D]) Min() (T, bool) {
+	if t.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return t.root.minNode().value, true
+}
+
+func (t *rbTree[T, D]) Max() (T, bool) {
+	if t.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return t.root.maxNode().value, true
+}
+
+func (t *rbTree[T, D]) InOrder(fn func(T)) {
+	if t.size == 0 {
+		return
+	}
+	t.root.inOrder(fn)
+}
+
+func (t *rbTree[T, D]) Delete(value T) bool {
+	if t.root.find(value, t.cmp) == nil {
+		return false
+	}
+
+	if !t.root.low.isRed() && !t.root.hight.isRed() {
+		t.root.red = rbRed
+	}
+	t.root = t.root.rm(value, t.cmp)
+	if t.root != nil {
+		t.root.red = rbBlack
+	}
+	t.size--
+
+	return true
+}
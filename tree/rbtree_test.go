@@ -0,0 +1,111 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestRBTree_MonotonicInsert(t *testing.T) {
+	tree := somedata.NewRBTree[int, int]()
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		tree.Insert(i, i*10)
+	}
+
+	if tree.Size() != n {
+		t.Fatalf("expected size %d, got %d", n, tree.Size())
+	}
+
+	got := []int{}
+	tree.InOrder(func(v int) { got = append(got, v) })
+	if len(got) != n || !slices.IsSorted(got) {
+		t.Fatalf("inOrder: expected %d sorted keys, got %d", n, len(got))
+	}
+
+	for i := 0; i < n; i++ {
+		data, ok := tree.Get(i)
+		if !ok || data != i*10 {
+			t.Fatalf("Get(%d): expected %d, got %d (%v)", i, i*10, data, ok)
+		}
+	}
+
+	if _, ok := tree.Get(n); ok {
+		t.Fatalf("Get(%d): expected missing key", n)
+	}
+
+	min, _ := tree.Min()
+	max, _ := tree.Max()
+	if min != 0 || max != n-1 {
+		t.Fatalf("expected min/max 0/%d, got %d/%d", n-1, min, max)
+	}
+}
+
+func TestRBTree_InsertExisting(t *testing.T) {
+	tree := somedata.NewRBTree[string, int]()
+	tree.Insert("a", 1)
+	tree.Insert("a", 2)
+
+	if tree.Size() != 1 {
+		t.Fatalf("expected size 1, got %d", tree.Size())
+	}
+	if data, _ := tree.Get("a"); data != 2 {
+		t.Fatalf("expected replaced data 2, got %d", data)
+	}
+}
+
+func TestRBTree_RandomDelete(t *testing.T) {
+	tree := somedata.NewRBTree[int, int]()
+	ref := map[int]int{}
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 5000; i++ {
+		key := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			_, exists := ref[key]
+			if tree.Delete(key) != exists {
+				t.Fatalf("Delete(%d): expected %v", key, exists)
+			}
+			delete(ref, key)
+			continue
+		}
+		tree.Insert(key, i)
+		ref[key] = i
+	}
+
+	if tree.Size() != len(ref) {
+		t.Fatalf("expected size %d, got %d", len(ref), tree.Size())
+	}
+
+	got := []int{}
+	tree.InOrder(func(v int) { got = append(got, v) })
+
+	expected := make([]int, 0, len(ref))
+	for k := range ref {
+		expected = append(expected, k)
+	}
+	slices.Sort(expected)
+
+	if !slices.Equal(got, expected) {
+		t.Fatalf("inOrder after deletes mismatch")
+	}
+
+	for k, v := range ref {
+		if data, ok := tree.Get(k); !ok || data != v {
+			t.Fatalf("Get(%d): expected %d, got %d", k, v, data)
+		}
+	}
+}
+
+func TestRBTree_DeleteEmpty(t *testing.T) {
+	tree := somedata.NewRBTree[int, any]()
+	if tree.Delete(1) {
+		t.Fatalf("expected Delete on empty tree = false")
+	}
+	if _, ok := tree.Min(); ok {
+		t.Fatalf("expected Min on empty tree = false")
+	}
+}
@@ -0,0 +1,382 @@
+package somedata
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RenderNode - tree agnostic node view used by WriteDOT and WriteASCII.
+// Binary trees keep exactly two children where nil marks a missing one
+type RenderNode[K any, D any] struct {
+	Keys     []K
+	Data     []D
+	Children []*RenderNode[K, D]
+}
+
+// RenderOptions - renderers output setup
+type RenderOptions[K any, D any] struct {
+	FormatKey  func(K) string // fmt.Sprint when nil
+	FormatData func(D) string // data is not rendered when nil
+	Heights    bool           // append subtree height to every node label
+}
+
+// heights - subtree heights of all nodes computed in one bottom-up pass
+func (n *RenderNode[K, D]) heights(dst map[*RenderNode[K, D]]int) int {
+	if n == nil {
+		return 0
+	}
+
+	h := 0
+	for _, c := range n.Children {
+		h = max(h, c.heights(dst))
+	}
+	dst[n] = h + 1
+	return h + 1
+}
+
+func (n *RenderNode[K, D]) hasChildren() bool {
+	for _, c := range n.Children {
+		if c != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeHeights - subtree heights of all nodes, nil when opts.Heights is off
+func (o RenderOptions[K, D]) nodeHeights(root *RenderNode[K, D]) map[*RenderNode[K, D]]int {
+	if !o.Heights || root == nil {
+		return nil
+	}
+
+	heights := make(map[*RenderNode[K, D]]int)
+	root.heights(heights)
+	return heights
+}
+
+// label - node text with keys, their data and subtree height from heights
+func (o RenderOptions[K, D]) label(n *RenderNode[K, D], heights map[*RenderNode[K, D]]int) string {
+	parts := make([]string, len(n.Keys))
+	for i, k := range n.Keys {
+		if o.FormatKey != nil {
+			parts[i] = o.FormatKey(k)
+		} else {
+			parts[i] = fmt.Sprint(k)
+		}
+		if o.FormatData != nil && i < len(n.Data) {
+			parts[i] += ": " + o.FormatData(n.Data[i])
+		}
+	}
+
+	label := strings.Join(parts, " | ")
+	if len(n.Keys) == 0 {
+		label = "·" // inner node without own keys
+		if o.FormatData != nil && len(n.Data) != 0 {
+			label += ": " + o.FormatData(n.Data[0])
+		}
+	}
+	if o.Heights {
+		label += fmt.Sprintf(" (h=%d)", heights[n])
+	}
+	return label
+}
+
+// renderWriter - keeps the first write error and skips all next writes
+type renderWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (rw *renderWriter) printf(format string, a ...any) {
+	if rw.err != nil {
+		return
+	}
+	_, rw.err = fmt.Fprintf(rw.w, format, a...)
+}
+
+// WriteDOT - writes tree in Graphviz DOT format
+func WriteDOT[K any, D any](w io.Writer, root *RenderNode[K, D], opts RenderOptions[K, D]) error {
+	rw := &renderWriter{w: w}
+	rw.printf("digraph tree {\n\tnode [shape=box];\n")
+	heights := opts.nodeHeights(root)
+
+	id := 0
+	var walk func(n *RenderNode[K, D]) int
+	walk = func(n *RenderNode[K, D]) int {
+		self := id
+		id++
+
+		rw.printf("\tn%d [label=\"%s\"];\n", self, dotEscape(opts.label(n, heights)))
+		if !n.hasChildren() {
+			return self
+		}
+
+		for _, c := range n.Children {
+			if c == nil {
+				rw.printf("\tn%d [shape=point];\n\tn%d -> n%d;\n", id, self, id)
+				id++
+				continue
+			}
+			rw.printf("\tn%d -> n%d;\n", self, walk(c))
+		}
+		return self
+	}
+
+	if root != nil {
+		walk(root)
+	}
+
+	rw.printf("}\n")
+	return rw.err
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WriteASCII - writes tree as indented ASCII diagram, one node per line
+func WriteASCII[K any, D any](w io.Writer, root *RenderNode[K, D], opts RenderOptions[K, D]) error {
+	rw := &renderWriter{w: w}
+	heights := opts.nodeHeights(root)
+
+	var walk func(n *RenderNode[K, D], prefix string)
+	walk = func(n *RenderNode[K, D], prefix string) {
+		if !n.hasChildren() {
+			return
+		}
+
+		for i, c := range n.Children {
+			branch, indent := "├── ", "│   "
+			if i == len(n.Children)-1 {
+				branch, indent = "└── ", "    "
+			}
+
+			if c == nil {
+				rw.printf("%s%s<nil>\n", prefix, branch)
+				continue
+			}
+			rw.printf("%s%s%s\n", prefix, branch, opts.label(c, heights))
+			walk(c, prefix+indent)
+		}
+	}
+
+	if root == nil {
+		rw.printf("<empty>\n")
+		return rw.err
+	}
+
+	rw.printf("%s\n", opts.label(root, heights))
+	walk(root, "")
+	return rw.err
+}
+
+func renderBinary[N any, K any, D any](n *N, view func(*N) (K, D, *N, *N)) *RenderNode[K, D] {
+	if n == nil {
+		return nil
+	}
+
+	key, data, low, hight := view(n)
+	return &RenderNode[K, D]{
+		Keys:     []K{key},
+		Data:     []D{data},
+		Children: []*RenderNode[K, D]{renderBinary(low, view), renderBinary(hight, view)},
+	}
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII
+func (t *threeBST[T, D]) Render() *RenderNode[T, D] {
+	return renderBinary(t.root, func(n *nodeBST[T, D]) (T, D, *nodeBST[T, D], *nodeBST[T, D]) {
+		return n.value, n.data, n.low, n.hight
+	})
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII
+func (t *rbTree[T, D]) Render() *RenderNode[T, D] {
+	return renderBinary(t.root, func(n *nodeRB[T, D]) (T, D, *nodeRB[T, D], *nodeRB[T, D]) {
+		return n.value, n.data, n.low, n.hight
+	})
+}
+
+// Render - shape of tree version for WriteDOT and WriteASCII
+func (t *persistentTree[T, D]) Render() *RenderNode[T, D] {
+	return renderBinary(t.root, func(n *nodePersistent[T, D]) (T, D, *nodePersistent[T, D], *nodePersistent[T, D]) {
+		return n.value, n.data, n.low, n.hight
+	})
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII
+func (t *intervalTree[T, D]) Render() *RenderNode[Interval[T], D] {
+	return renderBinary(t.root, func(n *nodeInterval[T, D]) (Interval[T], D, *nodeInterval[T, D], *nodeInterval[T, D]) {
+		return n.iv, n.data, n.low, n.hight
+	})
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII
+func (t *bTree[T, D]) Render() *RenderNode[T, D] {
+	var walk func(n *nodeB[T, D]) *RenderNode[T, D]
+	walk = func(n *nodeB[T, D]) *RenderNode[T, D] {
+		rn := &RenderNode[T, D]{Keys: n.keys, Data: n.data}
+		for _, c := range n.child {
+			rn.Children = append(rn.Children, walk(c))
+		}
+		return rn
+	}
+
+	if t.root == nil {
+		return nil
+	}
+	return walk(t.root)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII,
+// keys are edge labels, data is present only for nodes storing a value
+func (t *radixTree[K, D]) Render() *RenderNode[string, D] {
+	var walk func(n *nodeRadix[D]) *RenderNode[string, D]
+	walk = func(n *nodeRadix[D]) *RenderNode[string, D] {
+		rn := &RenderNode[string, D]{Keys: []string{n.prefix}}
+		if n.leaf {
+			rn.Data = []D{n.data}
+		}
+		for _, c := range n.edges {
+			rn.Children = append(rn.Children, walk(c))
+		}
+		return rn
+	}
+	return walk(t.root)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII,
+// keys are element positions with pending reverses applied
+func (t *treap[T]) Render() *RenderNode[int, T] {
+	var walk func(n *nodeTreap[T], offset int, reversed bool) *RenderNode[int, T]
+	walk = func(n *nodeTreap[T], offset int, reversed bool) *RenderNode[int, T] {
+		if n == nil {
+			return nil
+		}
+
+		reversed = reversed != n.reversed
+		low, hight := n.low, n.hight
+		if reversed {
+			low, hight = hight, low
+		}
+
+		pos := offset + low.cnt()
+		return &RenderNode[int, T]{
+			Keys:     []int{pos},
+			Data:     []T{n.value},
+			Children: []*RenderNode[int, T]{walk(low, offset, reversed), walk(hight, pos+1, reversed)},
+		}
+	}
+	return walk(t.root, 0, false)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII
+func (t *kdTree[T, D]) Render() *RenderNode[[]T, D] {
+	return renderBinary(t.root, func(n *nodeKD[T, D]) ([]T, D, *nodeKD[T, D], *nodeKD[T, D]) {
+		return n.Point, n.Data, n.low, n.hight
+	})
+}
+
+// Render - shape of quadtree or octree for WriteDOT and WriteASCII,
+// keys are points stored in node, empty regions are nil children
+func (t *spatialTree[T, D]) Render() *RenderNode[[]T, D] {
+	var walk func(n *nodeSpatial[T, D]) *RenderNode[[]T, D]
+	walk = func(n *nodeSpatial[T, D]) *RenderNode[[]T, D] {
+		if n.count == 0 && n != t.root {
+			return nil
+		}
+
+		rn := &RenderNode[[]T, D]{}
+		for _, item := range n.items {
+			rn.Keys = append(rn.Keys, item.Point)
+			rn.Data = append(rn.Data, item.Data)
+		}
+		for _, c := range n.child {
+			rn.Children = append(rn.Children, walk(c))
+		}
+		return rn
+	}
+	return walk(t.root)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII,
+// leaves are keyed by element index, inner nodes keep only combined value
+// as for n not power of two they may cover non-adjacent elements
+func (st *segmentTree[T]) Render() *RenderNode[int, T] {
+	var walk func(i int) *RenderNode[int, T]
+	walk = func(i int) *RenderNode[int, T] {
+		if i >= st.n {
+			return &RenderNode[int, T]{Keys: []int{i - st.n}, Data: []T{st.tree[i]}}
+		}
+		return &RenderNode[int, T]{
+			Data:     []T{st.tree[i]},
+			Children: []*RenderNode[int, T]{walk(2 * i), walk(2*i + 1)},
+		}
+	}
+
+	if st.n == 0 {
+		return nil
+	}
+	return walk(1)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII,
+// keys are half-open ranges [Lo, Hi) of nodes, pending updates
+// are applied to rendered values without pushing them down
+func (st *lazySegmentTree[T, U]) Render() *RenderNode[Interval[int], T] {
+	var walk func(node, l, r int, u U, pending bool) *RenderNode[Interval[int], T]
+	walk = func(node, l, r int, u U, pending bool) *RenderNode[Interval[int], T] {
+		value := st.tree[node]
+		if pending {
+			value = st.upd.Apply(u, value, r-l)
+		}
+		rn := &RenderNode[Interval[int], T]{
+			Keys: []Interval[int]{{Lo: l, Hi: r}},
+			Data: []T{value},
+		}
+		if r-l == 1 {
+			return rn
+		}
+
+		switch {
+		case st.pending[node] && pending:
+			u = st.upd.Compose(u, st.lazy[node])
+		case st.pending[node]:
+			u, pending = st.lazy[node], true
+		}
+
+		mid := (l + r) / 2
+		rn.Children = []*RenderNode[Interval[int], T]{
+			walk(2*node, l, mid, u, pending),
+			walk(2*node+1, mid, r, u, pending),
+		}
+		return rn
+	}
+
+	if st.n == 0 {
+		return nil
+	}
+	var none U
+	return walk(1, 0, st.n, none, false)
+}
+
+// Render - shape of tree for WriteDOT and WriteASCII,
+// node i is child of i - lowbit(i) and keeps sum of [Lo, Hi) range,
+// the root is virtual node 0 without keys
+func (ft *fenwick[T]) Render() *RenderNode[Interval[int], T] {
+	var walk func(i int) *RenderNode[Interval[int], T]
+	walk = func(i int) *RenderNode[Interval[int], T] {
+		rn := &RenderNode[Interval[int], T]{}
+		if i > 0 {
+			rn.Keys = []Interval[int]{{Lo: i - i&-i, Hi: i}}
+			rn.Data = []T{ft.tree[i]}
+		}
+
+		for step := 1; i+step < len(ft.tree) && (i == 0 || step < i&-i); step *= 2 {
+			rn.Children = append(rn.Children, walk(i+step))
+		}
+		return rn
+	}
+	return walk(0)
+}
@@ -0,0 +1,209 @@
+package somedata_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestRender_ASCII(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+	for _, v := range []int{50, 30, 70, 20, 80} {
+		tree.Insert(v, "")
+	}
+
+	var buf bytes.Buffer
+	err := somedata.WriteASCII(&buf, tree.Render(), somedata.RenderOptions[int, string]{Heights: true})
+	if err != nil {
+		t.Fatalf("WriteASCII: unexpected error %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"50 (h=3)",
+		"├── 30 (h=2)",
+		"│   ├── 20 (h=1)",
+		"│   └── <nil>",
+		"└── 70 (h=2)",
+		"    ├── <nil>",
+		"    └── 80 (h=1)",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Fatalf("WriteASCII: expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestRender_DOT(t *testing.T) {
+	tree := somedata.NewBTree[int, string](2)
+	for i, k := range []string{"a", "b", "c", "d"} {
+		tree.Set(i, k)
+	}
+
+	var buf bytes.Buffer
+	err := somedata.WriteDOT(&buf, tree.Render(), somedata.RenderOptions[int, string]{
+		FormatData: func(s string) string { return `"` + s + `"` },
+	})
+	if err != nil {
+		t.Fatalf("WriteDOT: unexpected error %v", err)
+	}
+
+	out := buf.String()
+	for _, line := range []string{
+		"digraph tree {",
+		`n0 [label="1: \"b\""];`,
+		`n1 [label="0: \"a\""];`,
+		`n2 [label="2: \"c\" | 3: \"d\""];`,
+		"n0 -> n1;",
+		"n0 -> n2;",
+	} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("WriteDOT: missing %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestRender_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	tree := somedata.NewRBTree[int, int]()
+
+	if err := somedata.WriteASCII(&buf, tree.Render(), somedata.RenderOptions[int, int]{}); err != nil {
+		t.Fatalf("WriteASCII: unexpected error %v", err)
+	}
+	if buf.String() != "<empty>\n" {
+		t.Fatalf("WriteASCII on empty tree: got %q", buf.String())
+	}
+}
+
+func TestRender_Treap(t *testing.T) {
+	tr := somedata.NewTreapFrom([]string{"a", "b", "c", "d", "e"})
+	tr.Reverse(0, 5)
+
+	got := []string{}
+	var walk func(n *somedata.RenderNode[int, string])
+	walk = func(n *somedata.RenderNode[int, string]) {
+		if n == nil {
+			return
+		}
+		walk(n.Children[0])
+		if len(got) != n.Keys[0] {
+			t.Fatalf("position key %d out of order", n.Keys[0])
+		}
+		got = append(got, n.Data[0])
+		walk(n.Children[1])
+	}
+	walk(tr.Render())
+
+	if strings.Join(got, "") != "edcba" {
+		t.Fatalf("in-order data of reversed treap: got %v", got)
+	}
+}
+
+func TestRender_SpatialAndKD(t *testing.T) {
+	q := somedata.NewQuadtree[int, int](16, 16)
+	for i := 0; i < 12; i++ {
+		q.Insert(i, i, i)
+	}
+
+	var buf bytes.Buffer
+	if err := somedata.WriteASCII(&buf, q.Render(), somedata.RenderOptions[[]int, int]{}); err != nil {
+		t.Fatalf("WriteASCII: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "·\n") || !strings.Contains(out, "[11 11]") || !strings.Contains(out, "<nil>") {
+		t.Fatalf("quadtree ASCII:\n%s", out)
+	}
+
+	kd := somedata.NewKDTree[int, string](2, [][]int{{1, 2}, {3, 4}, {5, 6}}, nil)
+	buf.Reset()
+	if err := somedata.WriteDOT(&buf, kd.Render(), somedata.RenderOptions[[]int, string]{}); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	if !strings.Contains(buf.String(), `label="[3 4]"`) {
+		t.Fatalf("k-d tree DOT:\n%s", buf.String())
+	}
+}
+
+func TestRender_HeightsChain(t *testing.T) {
+	tree := somedata.NewThreeBST[int, string]()
+	const n = 2000
+	for i := 0; i < n; i++ {
+		tree.Insert(i, "")
+	}
+
+	var buf bytes.Buffer
+	if err := somedata.WriteASCII(&buf, tree.Render(), somedata.RenderOptions[int, string]{Heights: true}); err != nil {
+		t.Fatalf("WriteASCII: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "0 (h=2000)\n") {
+		t.Fatalf("root label of degenerate chain: %q", strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+}
+
+func TestRender_SegmentAndFenwick(t *testing.T) {
+	var leaves []int
+	var walk func(n *somedata.RenderNode[int, int])
+	walk = func(n *somedata.RenderNode[int, int]) {
+		if len(n.Children) == 0 {
+			leaves = append(leaves, n.Keys[0], n.Data[0])
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+
+	st := somedata.NewSegmentTree(sumMonoid, []int{1, 2, 3, 4, 5})
+	root := st.Render()
+	walk(root)
+	if len(root.Keys) != 0 || root.Data[0] != 15 || len(leaves) != 10 {
+		t.Fatalf("segment tree render: root %v, leaves %v", root.Data, leaves)
+	}
+	for i := 0; i < len(leaves); i += 2 {
+		if leaves[i+1] != leaves[i]+1 {
+			t.Fatalf("segment tree leaf %d: got value %d", leaves[i], leaves[i+1])
+		}
+	}
+
+	lazy := somedata.NewLazySegmentTree(sumMonoid, addUpdate, []int{1, 2, 3, 4})
+	lazy.Update(0, 4, 10)
+	lazy.Update(2, 4, 100)
+
+	opts := somedata.RenderOptions[somedata.Interval[int], int]{FormatData: strconv.Itoa}
+	var buf bytes.Buffer
+	if err := somedata.WriteASCII(&buf, lazy.Render(), opts); err != nil {
+		t.Fatalf("WriteASCII: %v", err)
+	}
+	expected := strings.Join([]string{
+		"{0 4}: 250",
+		"├── {0 2}: 23",
+		"│   ├── {0 1}: 11",
+		"│   └── {1 2}: 12",
+		"└── {2 4}: 227",
+		"    ├── {2 3}: 113",
+		"    └── {3 4}: 114",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Fatalf("lazy segment tree ASCII: expected\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	buf.Reset()
+	ft := somedata.NewFenwickFrom([]int{1, 2, 3, 4, 5})
+	if err := somedata.WriteASCII(&buf, ft.Render(), opts); err != nil {
+		t.Fatalf("WriteASCII: %v", err)
+	}
+	expected = strings.Join([]string{
+		"·",
+		"├── {0 1}: 1",
+		"├── {0 2}: 3",
+		"│   └── {2 3}: 3",
+		"└── {0 4}: 10",
+		"    └── {4 5}: 5",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Fatalf("fenwick ASCII: expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
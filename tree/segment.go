@@ -0,0 +1,252 @@
+package somedata
+
+import (
+	"github.com/eterline/somedata"
+)
+
+// Monoid - associative operation with its identity element.
+// Combine(Identity, x) == Combine(x, Identity) == x
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a, b T) T
+}
+
+// LazyUpdate - range update applicable to segment values.
+// Apply - applies update to combined value of a segment with length elements.
+// Compose - merges update u into earlier pending update prev
+type LazyUpdate[T, U any] struct {
+	Apply   func(u U, value T, length int) T
+	Compose func(u, prev U) U
+}
+
+func checkSegmentRange(l, r, n int) {
+	if l < 0 || r > n || l > r {
+		panic(somedata.ErrSegmentOutOfRange(l, r, n))
+	}
+}
+
+/*
+segmentTree - flat bottom-up segment tree.
+Point update and range query are O(log n), ranges are half-open [l, r).
+*/
+type segmentTree[T any] struct {
+	n    int
+	m    Monoid[T]
+	tree []T // leaves are stored in tree[n:]
+}
+
+// NewSegmentTree - creates segment tree over copy of values
+func NewSegmentTree[T any](m Monoid[T], values []T) *segmentTree[T] {
+	n := len(values)
+	st := &segmentTree[T]{
+		n:    n,
+		m:    m,
+		tree: make([]T, 2*n),
+	}
+
+	copy(st.tree[n:], values)
+	for i := n - 1; i > 0; i-- {
+		st.tree[i] = m.Combine(st.tree[2*i], st.tree[2*i+1])
+	}
+	return st
+}
+
+func (st *segmentTree[T]) Len() int {
+	return st.n
+}
+
+// Get - value at index i
+func (st *segmentTree[T]) Get(i int) T {
+	checkSegmentRange(i, i+1, st.n)
+	return st.tree[st.n+i]
+}
+
+// Set - replaces value at index i
+func (st *segmentTree[T]) Set(i int, value T) {
+	checkSegmentRange(i, i+1, st.n)
+
+	i += st.n
+	st.tree[i] = value
+	for i > 1 {
+		i /= 2
+		st.tree[i] = st.m.Combine(st.tree[2*i], st.tree[2*i+1])
+	}
+}
+
+// Query - combined value over [l, r), identity for empty range
+func (st *segmentTree[T]) Query(l, r int) T {
+	checkSegmentRange(l, r, st.n)
+
+	resL, resR := st.m.Identity, st.m.Identity
+	for l, r = l+st.n, r+st.n; l < r; l, r = l/2, r/2 {
+		if l&1 == 1 {
+			resL = st.m.Combine(resL, st.tree[l])
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			resR = st.m.Combine(st.tree[r], resR)
+		}
+	}
+	return st.m.Combine(resL, resR)
+}
+
+/*
+lazySegmentTree - recursive segment tree with lazy propagation.
+Additionally to point operations supports range updates in O(log n),
+ranges are half-open [l, r).
+*/
+type lazySegmentTree[T, U any] struct {
+	n       int
+	m       Monoid[T]
+	upd     LazyUpdate[T, U]
+	tree    []T
+	lazy    []U
+	pending []bool
+}
+
+// NewLazySegmentTree - creates segment tree with range updates over copy of values
+func NewLazySegmentTree[T, U any](m Monoid[T], upd LazyUpdate[T, U], values []T) *lazySegmentTree[T, U] {
+	n := len(values)
+	size := 1
+	for size < 2*n {
+		size *= 2
+	}
+
+	st := &lazySegmentTree[T, U]{
+		n:       n,
+		m:       m,
+		upd:     upd,
+		tree:    make([]T, size),
+		lazy:    make([]U, size),
+		pending: make([]bool, size),
+	}
+
+	if n > 0 {
+		st.build(1, 0, n, values)
+	}
+	return st
+}
+
+func (st *lazySegmentTree[T, U]) build(node, l, r int, values []T) {
+	if r-l == 1 {
+		st.tree[node] = values[l]
+		return
+	}
+
+	mid := (l + r) / 2
+	st.build(2*node, l, mid, values)
+	st.build(2*node+1, mid, r, values)
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *lazySegmentTree[T, U]) apply(node, length int, u U) {
+	st.tree[node] = st.upd.Apply(u, st.tree[node], length)
+	if length == 1 {
+		return
+	}
+
+	if st.pending[node] {
+		st.lazy[node] = st.upd.Compose(u, st.lazy[node])
+	} else {
+		st.lazy[node] = u
+		st.pending[node] = true
+	}
+}
+
+// push - moves pending update of node to its children
+func (st *lazySegmentTree[T, U]) push(node, l, r int) {
+	if !st.pending[node] {
+		return
+	}
+
+	mid := (l + r) / 2
+	st.apply(2*node, mid-l, st.lazy[node])
+	st.apply(2*node+1, r-mid, st.lazy[node])
+
+	var zero U
+	st.lazy[node] = zero
+	st.pending[node] = false
+}
+
+func (st *lazySegmentTree[T, U]) update(node, l, r, ql, qr int, u U) {
+	if qr <= l || r <= ql {
+		return
+	}
+	if ql <= l && r <= qr {
+		st.apply(node, r-l, u)
+		return
+	}
+
+	st.push(node, l, r)
+	mid := (l + r) / 2
+	st.update(2*node, l, mid, ql, qr, u)
+	st.update(2*node+1, mid, r, ql, qr, u)
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *lazySegmentTree[T, U]) query(node, l, r, ql, qr int) T {
+	if qr <= l || r <= ql {
+		return st.m.Identity
+	}
+	if ql <= l && r <= qr {
+		return st.tree[node]
+	}
+
+	st.push(node, l, r)
+	mid := (l + r) / 2
+	return st.m.Combine(
+		st.query(2*node, l, mid, ql, qr),
+		st.query(2*node+1, mid, r, ql, qr),
+	)
+}
+
+func (st *lazySegmentTree[T, U]) set(node, l, r, i int, value T) {
+	if r-l == 1 {
+		st.tree[node] = value
+		return
+	}
+
+	st.push(node, l, r)
+	mid := (l + r) / 2
+	if i < mid {
+		st.set(2*node, l, mid, i, value)
+	} else {
+		st.set(2*node+1, mid, r, i, value)
+	}
+	st.tree[node] = st.m.Combine(st.tree[2*node], st.tree[2*node+1])
+}
+
+func (st *lazySegmentTree[T, U]) Len() int {
+	return st.n
+}
+
+// Get - value at index i
+func (st *lazySegmentTree[T, U]) Get(i int) T {
+	checkSegmentRange(i, i+1, st.n)
+	return st.query(1, 0, st.n, i, i+1)
+}
+
+// Set - replaces value at index i
+func (st *lazySegmentTree[T, U]) Set(i int, value T) {
+	checkSegmentRange(i, i+1, st.n)
+	st.set(1, 0, st.n, i, value)
+}
+
+// Query - combined value over [l, r), identity for empty range
+func (st *lazySegmentTree[T, U]) Query(l, r int) T {
+	checkSegmentRange(l, r, st.n)
+	if l == r {
+		return st.m.Identity
+	}
+	return st.query(1, 0, st.n, l, r)
+}
+
+// Update - applies u to every value in [l, r)
+func (st *lazySegmentTree[T, U]) Update(l, r int, u U) {
+	checkSegmentRange(l, r, st.n)
+	if l == r {
+		return
+	}
+	st.update(1, 0, st.n, l, r, u)
+}
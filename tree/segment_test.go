@@ -0,0 +1,100 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+var sumMonoid = somedata.Monoid[int]{
+	Identity: 0,
+	Combine:  func(a, b int) int { return a + b },
+}
+
+var addUpdate = somedata.LazyUpdate[int, int]{
+	Apply:   func(u, value, length int) int { return value + u*length },
+	Compose: func(u, prev int) int { return u + prev },
+}
+
+func TestSegmentTree_Query(t *testing.T) {
+	concat := somedata.Monoid[string]{
+		Identity: "",
+		Combine:  func(a, b string) string { return a + b },
+	}
+
+	st := somedata.NewSegmentTree(concat, []string{"a", "b", "c", "d", "e"})
+
+	if got := st.Query(1, 4); got != "bcd" {
+		t.Fatalf("Query(1, 4): expected bcd, got %q", got)
+	}
+	if got := st.Query(2, 2); got != "" {
+		t.Fatalf("Query(2, 2): expected empty, got %q", got)
+	}
+
+	st.Set(2, "X")
+	if got := st.Query(0, 5); got != "abXde" {
+		t.Fatalf("Query(0, 5) after Set: expected abXde, got %q", got)
+	}
+	if got := st.Get(2); got != "X" {
+		t.Fatalf("Get(2): expected X, got %q", got)
+	}
+}
+
+func TestSegmentTree_OutOfRange(t *testing.T) {
+	st := somedata.NewSegmentTree(sumMonoid, []int{1, 2, 3})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on out of range query")
+		}
+	}()
+	st.Query(1, 4)
+}
+
+func TestLazySegmentTree_RandomAgainstSlice(t *testing.T) {
+	rnd := rand.New(rand.NewSource(11))
+
+	ref := make([]int, 137)
+	for i := range ref {
+		ref[i] = rnd.Intn(100)
+	}
+	st := somedata.NewLazySegmentTree(sumMonoid, addUpdate, ref)
+
+	for op := 0; op < 3000; op++ {
+		l := rnd.Intn(len(ref) + 1)
+		r := l + rnd.Intn(len(ref)-l+1)
+
+		switch rnd.Intn(3) {
+		case 0:
+			delta := rnd.Intn(21) - 10
+			st.Update(l, r, delta)
+			for i := l; i < r; i++ {
+				ref[i] += delta
+			}
+
+		case 1:
+			if l == len(ref) {
+				continue
+			}
+			value := rnd.Intn(100)
+			st.Set(l, value)
+			ref[l] = value
+
+		default:
+			expected := 0
+			for i := l; i < r; i++ {
+				expected += ref[i]
+			}
+			if got := st.Query(l, r); got != expected {
+				t.Fatalf("Query(%d, %d): expected %d, got %d", l, r, expected, got)
+			}
+		}
+	}
+
+	for i, v := range ref {
+		if got := st.Get(i); got != v {
+			t.Fatalf("Get(%d): expected %d, got %d", i, v, got)
+		}
+	}
+}
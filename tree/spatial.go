@@ -0,0 +1,319 @@
+package somedata
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/eterline/somedata"
+	matrix "github.com/eterline/somedata/matrix"
+)
+
+const (
+	spatialNodeCapacity = 8  // points count in leaf before subdivision
+	spatialMaxDepth     = 24 // leaves on this depth are never subdivided
+)
+
+// SpatialPoint - point stored in quadtree or octree with attached data
+type SpatialPoint[T matrix.Numeric, D any] struct {
+	Point []T
+	Data  D
+}
+
+type nodeSpatial[T matrix.Numeric, D any] struct {
+	min   []float64 // region is half-open [min, max) by every axis
+	max   []float64
+	count int // points count in subtree
+	items []SpatialPoint[T, D]
+	child []*nodeSpatial[T, D] // empty for leaves, 2^dim otherwise
+}
+
+func (n *nodeSpatial[T, D]) contains(p []T) bool {
+	for i, v := range p {
+		if f := float64(v); f < n.min[i] || f >= n.max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// intersects - region has common points with closed box [lo, hi]
+func (n *nodeSpatial[T, D]) intersects(lo, hi []T) bool {
+	for i := range lo {
+		if float64(hi[i]) < n.min[i] || float64(lo[i]) >= n.max[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sqDistanceTo - squared distance from point to the closest point of region
+func (n *nodeSpatial[T, D]) sqDistanceTo(p []T) float64 {
+	var sum float64
+	for i, v := range p {
+		f := float64(v)
+		switch {
+		case f < n.min[i]:
+			sum += (n.min[i] - f) * (n.min[i] - f)
+		case f > n.max[i]:
+			sum += (f - n.max[i]) * (f - n.max[i])
+		}
+	}
+	return sum
+}
+
+func (n *nodeSpatial[T, D]) childIdx(p []T) int {
+	idx := 0
+	for i, v := range p {
+		if float64(v) >= (n.min[i]+n.max[i])/2 {
+			idx |= 1 << i
+		}
+	}
+	return idx
+}
+
+// subdivide - splits leaf on depth into 2^dim children
+func (n *nodeSpatial[T, D]) subdivide(depth int) {
+	dim := len(n.min)
+	n.child = make([]*nodeSpatial[T, D], 1<<dim)
+
+	for idx := range n.child {
+		c := &nodeSpatial[T, D]{
+			min: make([]float64, dim),
+			max: make([]float64, dim),
+		}
+		for i := 0; i < dim; i++ {
+			mid := (n.min[i] + n.max[i]) / 2
+			if idx&(1<<i) == 0 {
+				c.min[i], c.max[i] = n.min[i], mid
+			} else {
+				c.min[i], c.max[i] = mid, n.max[i]
+			}
+		}
+		n.child[idx] = c
+	}
+
+	for _, item := range n.items {
+		n.child[n.childIdx(item.Point)].insert(item, depth+1)
+	}
+	n.items = nil
+}
+
+func (n *nodeSpatial[T, D]) insert(item SpatialPoint[T, D], depth int) {
+	n.count++
+
+	if len(n.child) != 0 {
+		n.child[n.childIdx(item.Point)].insert(item, depth+1)
+		return
+	}
+
+	n.items = append(n.items, item)
+	if len(n.items) > spatialNodeCapacity && depth < spatialMaxDepth {
+		n.subdivide(depth)
+	}
+}
+
+// collect - appends all points of subtree to dst
+func (n *nodeSpatial[T, D]) collect(dst []SpatialPoint[T, D]) []SpatialPoint[T, D] {
+	dst = append(dst, n.items...)
+	for _, c := range n.child {
+		dst = c.collect(dst)
+	}
+	return dst
+}
+
+func (n *nodeSpatial[T, D]) rm(p []T) bool {
+	if len(n.child) == 0 {
+		i := slices.IndexFunc(n.items, func(item SpatialPoint[T, D]) bool {
+			return slices.Equal(item.Point, p)
+		})
+		if i < 0 {
+			return false
+		}
+		n.items = slices.Delete(n.items, i, i+1)
+		n.count--
+		return true
+	}
+
+	if !n.child[n.childIdx(p)].rm(p) {
+		return false
+	}
+
+	n.count--
+	if n.count <= spatialNodeCapacity {
+		n.items = n.collect(make([]SpatialPoint[T, D], 0, n.count))
+		n.child = nil
+	}
+	return true
+}
+
+func (n *nodeSpatial[T, D]) query(lo, hi []T, yield func(SpatialPoint[T, D]) bool) bool {
+	if n.count == 0 || !n.intersects(lo, hi) {
+		return true
+	}
+
+	for _, item := range n.items {
+		inside := true
+		for i, v := range item.Point {
+			if v < lo[i] || v > hi[i] {
+				inside = false
+				break
+			}
+		}
+		if inside && !yield(item) {
+			return false
+		}
+	}
+
+	for _, c := range n.child {
+		if !c.query(lo, hi, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *nodeSpatial[T, D]) nearest(p []T, best **SpatialPoint[T, D], bestDist *float64) {
+	if n.count == 0 || n.sqDistanceTo(p) >= *bestDist {
+		return
+	}
+
+	for i := range n.items {
+		if d := sqDistance(p, n.items[i].Point); d < *bestDist {
+			*best, *bestDist = &n.items[i], d
+		}
+	}
+
+	if len(n.child) == 0 {
+		return
+	}
+
+	// the child containing point first gives the tightest bound early
+	first := n.childIdx(p)
+	n.child[first].nearest(p, best, bestDist)
+	for idx, c := range n.child {
+		if idx != first {
+			c.nearest(p, best, bestDist)
+		}
+	}
+}
+
+/*
+spatialTree - region tree over 2^dim subdivisions,
+shared realization of quadtree (dim = 2) and octree (dim = 3).
+*/
+type spatialTree[T matrix.Numeric, D any] struct {
+	root *nodeSpatial[T, D]
+}
+
+func newSpatialTree[T matrix.Numeric, D any](sizes ...T) spatialTree[T, D] {
+	root := &nodeSpatial[T, D]{
+		min: make([]float64, len(sizes)),
+		max: make([]float64, len(sizes)),
+	}
+	for i, s := range sizes {
+		if s <= 0 {
+			panic(somedata.ErrSpatialInvalidBounds)
+		}
+		root.max[i] = float64(s)
+	}
+	return spatialTree[T, D]{root: root}
+}
+
+func (t *spatialTree[T, D]) Size() int {
+	return t.root.count
+}
+
+func (t *spatialTree[T, D]) insert(p []T, data D) bool {
+	if !t.root.contains(p) {
+		return false
+	}
+	t.root.insert(SpatialPoint[T, D]{Point: p, Data: data}, 0)
+	return true
+}
+
+func (t *spatialTree[T, D]) remove(p []T) bool {
+	if !t.root.contains(p) {
+		return false
+	}
+	return t.root.rm(p)
+}
+
+func (t *spatialTree[T, D]) query(lo, hi []T) iter.Seq[SpatialPoint[T, D]] {
+	return func(yield func(SpatialPoint[T, D]) bool) {
+		t.root.query(lo, hi, yield)
+	}
+}
+
+func (t *spatialTree[T, D]) nearest(p []T) (SpatialPoint[T, D], bool) {
+	var (
+		best     *SpatialPoint[T, D]
+		bestDist = math.Inf(1)
+	)
+
+	t.root.nearest(p, &best, &bestDist)
+	if best == nil {
+		return SpatialPoint[T, D]{}, false
+	}
+	return *best, true
+}
+
+// quadtree - 2D region tree over [0, width) × [0, height)
+type quadtree[T matrix.Numeric, D any] struct {
+	spatialTree[T, D]
+}
+
+// NewQuadtree - creates quadtree with the same bounds as NewMatrix2(width, height)
+func NewQuadtree[T matrix.Numeric, D any](width, height T) *quadtree[T, D] {
+	return &quadtree[T, D]{newSpatialTree[T, D](width, height)}
+}
+
+// Insert - adds point with data, returns false for points out of bounds
+func (t *quadtree[T, D]) Insert(x, y T, data D) bool {
+	return t.insert([]T{x, y}, data)
+}
+
+// Remove - removes one point with given coordinates
+func (t *quadtree[T, D]) Remove(x, y T) bool {
+	return t.remove([]T{x, y})
+}
+
+// QueryRect - iterator over points inside of [x, x+width] × [y, y+height]
+func (t *quadtree[T, D]) QueryRect(x, y, width, height T) iter.Seq[SpatialPoint[T, D]] {
+	return t.query([]T{x, y}, []T{x + width, y + height})
+}
+
+// Nearest - the closest point to (x, y)
+func (t *quadtree[T, D]) Nearest(x, y T) (SpatialPoint[T, D], bool) {
+	return t.nearest([]T{x, y})
+}
+
+// octree - 3D region tree over [0, width) × [0, height) × [0, deep)
+type octree[T matrix.Numeric, D any] struct {
+	spatialTree[T, D]
+}
+
+// NewOctree - creates octree with the same bounds as NewMatrix3(width, height, deep)
+func NewOctree[T matrix.Numeric, D any](width, height, deep T) *octree[T, D] {
+	return &octree[T, D]{newSpatialTree[T, D](width, height, deep)}
+}
+
+// Insert - adds point with data, returns false for points out of bounds
+func (t *octree[T, D]) Insert(x, y, z T, data D) bool {
+	return t.insert([]T{x, y, z}, data)
+}
+
+// Remove - removes one point with given coordinates
+func (t *octree[T, D]) Remove(x, y, z T) bool {
+	return t.remove([]T{x, y, z})
+}
+
+// QueryBox - iterator over points inside of [x, x+width] × [y, y+height] × [z, z+deep]
+func (t *octree[T, D]) QueryBox(x, y, z, width, height, deep T) iter.Seq[SpatialPoint[T, D]] {
+	return t.query([]T{x, y, z}, []T{x + width, y + height, z + deep})
+}
+
+// Nearest - the closest point to (x, y, z)
+func (t *octree[T, D]) Nearest(x, y, z T) (SpatialPoint[T, D], bool) {
+	return t.nearest([]T{x, y, z})
+}
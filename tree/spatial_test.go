@@ -0,0 +1,134 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"testing"
+
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestQuadtree_InsertQueryRemove(t *testing.T) {
+	rnd := rand.New(rand.NewSource(19))
+	tree := somedata.NewQuadtree[int, int](100, 50)
+
+	if tree.Insert(100, 0, -1) || tree.Insert(-1, 0, -1) {
+		t.Fatalf("Insert: expected false for point out of bounds")
+	}
+
+	points := make([][2]int, 400)
+	for i := range points {
+		points[i] = [2]int{rnd.Intn(100), rnd.Intn(50)}
+		if !tree.Insert(points[i][0], points[i][1], i) {
+			t.Fatalf("Insert(%v): expected true", points[i])
+		}
+	}
+
+	countRect := func(x, y, w, h int) int {
+		n := 0
+		for _, p := range points {
+			if p[0] >= x && p[0] <= x+w && p[1] >= y && p[1] <= y+h {
+				n++
+			}
+		}
+		return n
+	}
+
+	for q := 0; q < 50; q++ {
+		x, y, w, h := rnd.Intn(100), rnd.Intn(50), rnd.Intn(40), rnd.Intn(20)
+		got := 0
+		for range tree.QueryRect(x, y, w, h) {
+			got++
+		}
+		if expected := countRect(x, y, w, h); got != expected {
+			t.Fatalf("QueryRect(%d, %d, %d, %d): expected %d, got %d", x, y, w, h, expected, got)
+		}
+	}
+
+	for _, p := range points[:300] {
+		if !tree.Remove(p[0], p[1]) {
+			t.Fatalf("Remove(%v): expected true", p)
+		}
+	}
+	points = points[300:]
+
+	if tree.Size() != len(points) {
+		t.Fatalf("expected size %d, got %d", len(points), tree.Size())
+	}
+	for q := 0; q < 50; q++ {
+		x, y, w, h := rnd.Intn(100), rnd.Intn(50), rnd.Intn(40), rnd.Intn(20)
+		got := 0
+		for range tree.QueryRect(x, y, w, h) {
+			got++
+		}
+		if expected := countRect(x, y, w, h); got != expected {
+			t.Fatalf("QueryRect(%d, %d, %d, %d) after Remove: expected %d, got %d", x, y, w, h, expected, got)
+		}
+	}
+}
+
+func TestQuadtree_DuplicatePoints(t *testing.T) {
+	tree := somedata.NewQuadtree[int, int](10, 10)
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		tree.Insert(5, 5, i)
+	}
+	tree.Insert(1, 1, -1)
+
+	seen := map[int]bool{}
+	for p := range tree.QueryRect(5, 5, 0, 0) {
+		seen[p.Data] = true
+	}
+	if len(seen) != n {
+		t.Fatalf("QueryRect of duplicates: expected %d points, got %d", n, len(seen))
+	}
+
+	for i := 0; i < n; i++ {
+		if !tree.Remove(5, 5) {
+			t.Fatalf("Remove #%d of duplicate point: expected true", i)
+		}
+	}
+	if tree.Remove(5, 5) || tree.Size() != 1 {
+		t.Fatalf("expected only (1, 1) left, got size %d", tree.Size())
+	}
+}
+
+func TestOctree_Nearest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(23))
+	tree := somedata.NewOctree[float64, int](10, 20, 30)
+
+	if _, ok := tree.Nearest(1, 1, 1); ok {
+		t.Fatalf("Nearest on empty tree: expected false")
+	}
+
+	points := make([][3]float64, 300)
+	for i := range points {
+		points[i] = [3]float64{rnd.Float64() * 10, rnd.Float64() * 20, rnd.Float64() * 30}
+		tree.Insert(points[i][0], points[i][1], points[i][2], i)
+	}
+
+	for q := 0; q < 100; q++ {
+		query := [3]float64{rnd.Float64() * 10, rnd.Float64() * 20, rnd.Float64() * 30}
+
+		best, bestDist := -1, 0.0
+		for i, p := range points {
+			d := (p[0]-query[0])*(p[0]-query[0]) + (p[1]-query[1])*(p[1]-query[1]) + (p[2]-query[2])*(p[2]-query[2])
+			if best < 0 || d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+
+		found, ok := tree.Nearest(query[0], query[1], query[2])
+		if !ok || found.Data != best {
+			t.Fatalf("Nearest(%v): expected point %d, got %d", query, best, found.Data)
+		}
+	}
+
+	got := 0
+	for range tree.QueryBox(0, 0, 0, 10, 20, 30) {
+		got++
+	}
+	if got != len(points) {
+		t.Fatalf("QueryBox over bounds: expected %d, got %d", len(points), got)
+	}
+}
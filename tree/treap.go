@@ -0,0 +1,229 @@
+package somedata
+
+import (
+	"iter"
+	"math/rand/v2"
+
+	"github.com/eterline/somedata"
+)
+
+type nodeTreap[T any] struct {
+	value    T
+	priority uint64
+	count    int  // nodes count in subtree including itself
+	reversed bool // pending reverse of subtree
+	low      *nodeTreap[T]
+	hight    *nodeTreap[T]
+}
+
+func (n *nodeTreap[T]) cnt() int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+func (n *nodeTreap[T]) update() {
+	n.count = n.low.cnt() + n.hight.cnt() + 1
+}
+
+// push - moves pending reverse to children
+func (n *nodeTreap[T]) push() {
+	if n == nil || !n.reversed {
+		return
+	}
+
+	n.low, n.hight = n.hight, n.low
+	if n.low != nil {
+		n.low.reversed = !n.low.reversed
+	}
+	if n.hight != nil {
+		n.hight.reversed = !n.hight.reversed
+	}
+	n.reversed = false
+}
+
+// splitAt - splits subtree into first k elements and the rest
+func (n *nodeTreap[T]) splitAt(k int) (*nodeTreap[T], *nodeTreap[T]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	n.push()
+	if n.low.cnt() < k {
+		low, hight := n.hight.splitAt(k - n.low.cnt() - 1)
+		n.hight = low
+		n.update()
+		return n, hight
+	}
+
+	low, hight := n.low.splitAt(k)
+	n.low = hight
+	n.update()
+	return low, n
+}
+
+// mergeTreap - concatenates two subtrees keeping heap order of priorities
+func mergeTreap[T any](low, hight *nodeTreap[T]) *nodeTreap[T] {
+	if low == nil {
+		return hight
+	}
+	if hight == nil {
+		return low
+	}
+
+	if low.priority > hight.priority {
+		low.push()
+		low.hight = mergeTreap(low.hight, hight)
+		low.update()
+		return low
+	}
+
+	hight.push()
+	hight.low = mergeTreap(low, hight.low)
+	hight.update()
+	return hight
+}
+
+func (n *nodeTreap[T]) at(i int) *nodeTreap[T] {
+	cur := n
+	for {
+		cur.push()
+		low := cur.low.cnt()
+		switch {
+		case i < low:
+			cur = cur.low
+		case i > low:
+			i -= low + 1
+			cur = cur.hight
+		default:
+			return cur
+		}
+	}
+}
+
+func (n *nodeTreap[T]) walk(yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	n.push()
+	return n.low.walk(yield) && yield(n.value) && n.hight.walk(yield)
+}
+
+/*
+treap - randomized BST with implicit keys.
+Elements are addressed by position like in a slice, but insertion,
+removal, split, merge and range reverse at any position take O(log n)
+expected time.
+*/
+type treap[T any] struct {
+	root *nodeTreap[T]
+}
+
+// NewTreap - creates implicit-key treap
+func NewTreap[T any]() *treap[T] {
+	return &treap[T]{}
+}
+
+// NewTreapFrom - creates implicit-key treap with copy of values
+func NewTreapFrom[T any](values []T) *treap[T] {
+	t := &treap[T]{}
+	for _, v := range values {
+		t.PushBack(v)
+	}
+	return t
+}
+
+func (t *treap[T]) Len() int {
+	return t.root.cnt()
+}
+
+func (t *treap[T]) checkRange(i, n int) {
+	if i < 0 || i >= n {
+		panic(somedata.ErrTreapOutOfRange(i, t.Len()))
+	}
+}
+
+// At - element at index i
+func (t *treap[T]) At(i int) T {
+	t.checkRange(i, t.Len())
+	return t.root.at(i).value
+}
+
+// Set - replaces element at index i
+func (t *treap[T]) Set(i int, value T) {
+	t.checkRange(i, t.Len())
+	t.root.at(i).value = value
+}
+
+// Insert - inserts item so it becomes element at index at, at == Len() appends
+func (t *treap[T]) Insert(at int, item T) {
+	t.checkRange(at, t.Len()+1)
+
+	node := &nodeTreap[T]{value: item, priority: rand.Uint64(), count: 1}
+	low, hight := t.root.splitAt(at)
+	t.root = mergeTreap(mergeTreap(low, node), hight)
+}
+
+// PushBack - appends element to the end
+func (t *treap[T]) PushBack(item T) {
+	node := &nodeTreap[T]{value: item, priority: rand.Uint64(), count: 1}
+	t.root = mergeTreap(t.root, node)
+}
+
+// Remove - removes and returns element at index at
+func (t *treap[T]) Remove(at int) T {
+	t.checkRange(at, t.Len())
+
+	low, rest := t.root.splitAt(at)
+	node, hight := rest.splitAt(1)
+	t.root = mergeTreap(low, hight)
+	return node.value
+}
+
+// Split - moves the first at elements into the first treap and the rest
+// into the second one. Receiver becomes empty
+func (t *treap[T]) Split(at int) (*treap[T], *treap[T]) {
+	t.checkRange(at, t.Len()+1)
+
+	low, hight := t.root.splitAt(at)
+	t.root = nil
+	return &treap[T]{root: low}, &treap[T]{root: hight}
+}
+
+// Merge - appends all elements of other to the end. Other becomes empty
+func (t *treap[T]) Merge(other *treap[T]) {
+	t.root = mergeTreap(t.root, other.root)
+	other.root = nil
+}
+
+// Reverse - reverses order of elements in [l, r)
+func (t *treap[T]) Reverse(l, r int) {
+	if l < 0 || r > t.Len() || l > r {
+		panic(somedata.ErrTreapInvalidRange(l, r, t.Len()))
+	}
+	if r-l < 2 {
+		return
+	}
+
+	low, rest := t.root.splitAt(l)
+	mid, hight := rest.splitAt(r - l)
+	mid.reversed = !mid.reversed
+	t.root = mergeTreap(mergeTreap(low, mid), hight)
+}
+
+// Iter - iterator over elements from index 0 to Len()-1
+func (t *treap[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.root.walk(yield)
+	}
+}
+
+// Slice - elements as a slice
+func (t *treap[T]) Slice() []T {
+	slc := make([]T, 0, t.Len())
+	for v := range t.Iter() {
+		slc = append(slc, v)
+	}
+	return slc
+}
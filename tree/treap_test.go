@@ -0,0 +1,106 @@
+package somedata_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	root "github.com/eterline/somedata"
+	somedata "github.com/eterline/somedata/tree"
+)
+
+func TestTreap_InsertRemove(t *testing.T) {
+	tr := somedata.NewTreapFrom([]string{"a", "c", "e"})
+
+	tr.Insert(1, "b")
+	tr.Insert(3, "d")
+	tr.Insert(5, "f")
+	tr.Insert(0, "_")
+
+	if got := tr.Slice(); !slices.Equal(got, []string{"_", "a", "b", "c", "d", "e", "f"}) {
+		t.Fatalf("Insert: got %v", got)
+	}
+
+	if v := tr.Remove(0); v != "_" {
+		t.Fatalf("Remove(0): expected _, got %s", v)
+	}
+	if v := tr.Remove(3); v != "d" {
+		t.Fatalf("Remove(3): expected d, got %s", v)
+	}
+	tr.Set(0, "A")
+
+	if got := tr.Slice(); !slices.Equal(got, []string{"A", "b", "c", "e", "f"}) || tr.Len() != 5 {
+		t.Fatalf("after Remove/Set: got %v", got)
+	}
+	if v := tr.At(3); v != "e" {
+		t.Fatalf("At(3): expected e, got %s", v)
+	}
+}
+
+func TestTreap_SplitMergeReverse(t *testing.T) {
+	tr := somedata.NewTreapFrom([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	tr.Reverse(2, 7)
+	if got := tr.Slice(); !slices.Equal(got, []int{0, 1, 6, 5, 4, 3, 2, 7, 8, 9}) {
+		t.Fatalf("Reverse(2, 7): got %v", got)
+	}
+
+	left, right := tr.Split(4)
+	if tr.Len() != 0 || left.Len() != 4 || right.Len() != 6 {
+		t.Fatalf("Split: unexpected lengths %d %d %d", tr.Len(), left.Len(), right.Len())
+	}
+
+	right.Merge(left)
+	if got := right.Slice(); !slices.Equal(got, []int{4, 3, 2, 7, 8, 9, 0, 1, 6, 5}) || left.Len() != 0 {
+		t.Fatalf("Merge: got %v", got)
+	}
+}
+
+func TestTreap_RandomAgainstSlice(t *testing.T) {
+	rnd := rand.New(rand.NewSource(13))
+	tr := somedata.NewTreap[int]()
+	ref := []int{}
+
+	for op := 0; op < 5000; op++ {
+		switch rnd.Intn(4) {
+		case 0, 1:
+			at := rnd.Intn(len(ref) + 1)
+			tr.Insert(at, op)
+			ref = slices.Insert(ref, at, op)
+		case 2:
+			if len(ref) == 0 {
+				continue
+			}
+			at := rnd.Intn(len(ref))
+			if v := tr.Remove(at); v != ref[at] {
+				t.Fatalf("Remove(%d): expected %d, got %d", at, ref[at], v)
+			}
+			ref = slices.Delete(ref, at, at+1)
+		default:
+			l := rnd.Intn(len(ref) + 1)
+			r := l + rnd.Intn(len(ref)-l+1)
+			tr.Reverse(l, r)
+			slices.Reverse(ref[l:r])
+		}
+	}
+
+	if got := tr.Slice(); !slices.Equal(got, ref) {
+		t.Fatalf("treap content mismatch")
+	}
+}
+
+func TestTreap_ReverseInvalidRange(t *testing.T) {
+	tr := somedata.NewTreapFrom([]int{1, 2, 3, 4})
+
+	for _, bounds := range [][2]int{{-1, 2}, {3, 1}, {0, 5}} {
+		func() {
+			expected := root.ErrTreapInvalidRange(bounds[0], bounds[1], 4)
+			defer func() {
+				if got := recover(); got != expected {
+					t.Fatalf("Reverse(%d, %d): expected panic %q, got %v", bounds[0], bounds[1], expected, got)
+				}
+			}()
+			tr.Reverse(bounds[0], bounds[1])
+		}()
+	}
+}